@@ -17,12 +17,15 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// NOTE(bandini): If you change anything in the following two lines you need to update
-// ./scripts/update-cnsa-versions-metadata.sh
-// +kubebuilder:validation:Enum=v5.2.3.1.dev3
+// StorageScaleVersions is one of the IBM Storage Scale releases shipped with the operator. The available
+// versions are published as StorageScaleRelease objects and enforced by the webhook. Without the webhook
+// a version that is not shipped is reported in the ManifestApply condition
+// +kubebuilder:validation:Pattern=`^v?[0-9]+(\.[0-9a-z]+)+$`
 type StorageScaleVersions string
 
 // FusionAccessSpec defines the desired state of FusionAccess
@@ -36,10 +39,120 @@ type FusionAccessSpec struct {
 
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden"}
 	LocalVolumeDiscovery StorageDeviceDiscovery `json:"storageDeviceDiscovery,omitempty"`
+	// ExternalManifestURL loads the IBM manifest from a URL instead of the manifest shipped with the operator.
+	// The last verified manifest is kept in the fusionaccess-manifest-cache ConfigMap and used while the URL
+	// cannot be loaded, also after the operator was restarted
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden"}
 	// +kubebuilder:validation:Format=uri
 	ExternalManifestURL string `json:"externalManifestURL,omitempty"`
+	// ExternalManifestDigest is the sha256 digest of the manifest at externalManifestURL, e.g. "sha256:0123...".
+	// When set, the fetched manifest is only applied if its content matches
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=7,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden"}
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	// +optional
+	ExternalManifestDigest string `json:"externalManifestDigest,omitempty"`
+	// CorrectManifestDrift re-applies the IBM manifest when the periodic drift check finds IBM resources
+	// that were changed or deleted by hand. When false the drift is only reported
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden"}
+	// +optional
+	CorrectManifestDrift bool `json:"correctManifestDrift,omitempty"`
+	// ManifestPatches are ConfigMaps in the FusionAccess namespace whose entries are patches layered onto
+	// the IBM manifest before it is applied. Each entry is a YAML document with a target (kind, name and
+	// optionally namespace), a type ("strategic", the default, or "json6902") and the patch itself
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=6,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden"}
+	// +optional
+	ManifestPatches []corev1.LocalObjectReference `json:"manifestPatches,omitempty"`
+	// ManifestSource loads the IBM manifest from ConfigMaps or an OCI artifact instead of the manifest
+	// shipped with the operator, e.g. on disconnected clusters. It cannot be combined with externalManifestURL.
+	// As with externalManifestURL, the last verified manifest is used while the source cannot be loaded
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=8,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden"}
+	// +optional
+	ManifestSource *ManifestSource `json:"manifestSource,omitempty"`
+	// StorageCluster is the IBM Storage Scale Cluster the operator creates once the IBM operator is
+	// available. Removing it does not delete the Cluster, which holds the filesystems
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=9,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden"}
+	// +optional
+	StorageCluster *StorageClusterSpec `json:"storageCluster,omitempty"`
+	// StorageNodes are the nodes the operator labels with scale.spectrum.ibm.com/role=storage. The label is
+	// removed from the nodes that leave the selection, unless they still host LocalDisks
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=10,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden"}
+	// +optional
+	StorageNodes *StorageNodesSpec `json:"storageNodes,omitempty"`
 }
+
+// StorageNodesSpec selects the storage nodes. Exactly one of nodeSelector and nodes must be set
+type StorageNodesSpec struct {
+	// NodeSelector selects the storage nodes by their labels
+	// +optional
+	NodeSelector *corev1.NodeSelector `json:"nodeSelector,omitempty"`
+	// Nodes are the names of the storage nodes
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// StorageClusterSpec is the part of the IBM Storage Scale Cluster managed through FusionAccess. The other
+// fields of the Cluster are left to the IBM operator
+type StorageClusterSpec struct {
+	// License is the IBM Storage Scale edition and its acceptance
+	License StorageClusterLicense `json:"license"`
+	// DaemonNodeSelector selects the nodes that run the Storage Scale daemons. It defaults to the nodes
+	// labelled scale.spectrum.ibm.com/role=storage
+	// +optional
+	DaemonNodeSelector map[string]string `json:"daemonNodeSelector,omitempty"`
+	// PMCollectorNodeSelector selects the nodes that run the performance monitoring collectors. It defaults
+	// to the nodes labelled scale.spectrum.ibm.com/role=storage
+	// +optional
+	PMCollectorNodeSelector map[string]string `json:"pmcollectorNodeSelector,omitempty"`
+	// ClusterProfile overrides Storage Scale configuration parameters of the daemons, e.g. "pagepoolMaxPhysMemPct".
+	// IBM does not support changing them unless advised by IBM Support
+	// +optional
+	ClusterProfile map[string]string `json:"clusterProfile,omitempty"`
+}
+
+// StorageClusterLicense is the license of the IBM Storage Scale Cluster
+type StorageClusterLicense struct {
+	// Accept must be true to accept the IBM Storage Scale license,
+	// see https://www.ibm.com/support/customer/csol/terms/?id=L-WWVS-K7K7DR
+	// +kubebuilder:validation:Enum=true
+	Accept bool `json:"accept"`
+	// Edition is the IBM Storage Scale edition
+	// +kubebuilder:validation:Enum=data-access;data-management;erasure-code
+	// +kubebuilder:default:=data-management
+	// +optional
+	Edition string `json:"edition,omitempty"`
+}
+
+// ManifestSource is where an IBM manifest that is not shipped with the operator is loaded from.
+// Exactly one of configMaps and ociArtifact must be set
+type ManifestSource struct {
+	// ConfigMaps in the FusionAccess namespace hold the manifest in their "install.yaml" key. The content
+	// of all ConfigMaps is concatenated in order, so a manifest larger than the size limit of a single
+	// ConfigMap can be split across several
+	// +optional
+	ConfigMaps []corev1.LocalObjectReference `json:"configMaps,omitempty"`
+	// OCIArtifact is an OCI artifact, e.g. in the mirror registry, that holds the manifest
+	// +optional
+	OCIArtifact *OCIArtifactSource `json:"ociArtifact,omitempty"`
+}
+
+// OCIArtifactSource is an OCI artifact whose layer holds the manifest, e.g. one pushed with
+// "oras push <image> install.yaml"
+type OCIArtifactSource struct {
+	// Image is the artifact reference, e.g. "mirror.example.com/fusion/manifests@sha256:0123...".
+	// A digest reference is recommended, the content is then verified against it and pulled only once.
+	// A tag is pulled again at most every 10 minutes
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+	// PullSecret is a kubernetes.io/dockerconfigjson secret in the FusionAccess namespace with the registry
+	// credentials. The global pull secret is used when it is not set
+	// +optional
+	PullSecret *corev1.LocalObjectReference `json:"pullSecret,omitempty"`
+	// CABundle is a ConfigMap in the FusionAccess namespace whose "ca-bundle.crt" key holds the CA
+	// certificates of the registry, in addition to the system ones
+	// +optional
+	CABundle *corev1.LocalObjectReference `json:"caBundle,omitempty"`
+}
+
 type StorageDeviceDiscovery struct {
 	// +kubebuilder:default:=true
 	Create bool `json:"create,omitempty"`
@@ -51,17 +164,282 @@ type FusionAccessStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 	// Conditions is a list of conditions and their status.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
-	// TotalProvisionedDeviceCount is the count of the total devices over which the PVs has been provisioned
+	// TotalProvisionedDeviceCount is the count of the total devices claimed by IBM Storage Scale as LocalDisks
 	TotalProvisionedDeviceCount *int32 `json:"totalProvisionedDeviceCount,omitempty"`
 	// observedGeneration is the last generation change the operator has dealt with
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// Show the general status of the fusion access object (this can be shown nicely on ocp console UI)
 	Status string `json:"status,omitempty"`
+	// InstalledStorageScaleVersion is the IBM Storage Scale version that is fully rolled out on the cluster.
+	// It differs from spec.storageScaleVersion while an upgrade is in progress
+	// +optional
+	InstalledStorageScaleVersion string `json:"installedStorageScaleVersion,omitempty"`
+	// ImagePullCheck is the outcome of the last verification that the IBM images can be pulled
+	// with the entitlement key. It is only repeated when the image or the entitlement key change
+	// +optional
+	ImagePullCheck *ImagePullCheckStatus `json:"imagePullCheck,omitempty"`
+	// Capacity is the raw capacity of the devices found by the device discovery and of the devices
+	// claimed by IBM Storage Scale as LocalDisks, in total and for each node. A LUN shared by several
+	// nodes is counted once in the total
+	// +optional
+	Capacity *CapacityStatus `json:"capacity,omitempty"`
+	// Manifest records the last IBM manifest that was applied and when the IBM resources were last
+	// compared with it
+	// +optional
+	Manifest *ManifestStatus `json:"manifest,omitempty"`
+	// DryRunPlan points to the plan computed while the fusion.storage.openshift.io/dry-run annotation
+	// is set to "true". Nothing is changed on the cluster while it is set
+	// +optional
+	DryRunPlan *DryRunPlanStatus `json:"dryRunPlan,omitempty"`
+	// StorageNodes are the nodes labelled as storage nodes from spec.storageNodes
+	// +optional
+	StorageNodes *StorageNodesStatus `json:"storageNodes,omitempty"`
 }
 
+// StorageNodesStatus reports the nodes the operator labels with the storage role
+type StorageNodesStatus struct {
+	// Selected are the nodes selected by spec.storageNodes
+	// +optional
+	Selected []string `json:"selected,omitempty"`
+	// Retained are the nodes that are not selected anymore but keep the storage role as they host LocalDisks
+	// +optional
+	Retained []string `json:"retained,omitempty"`
+}
+
+// DryRunPlanStatus summarizes what the operator would do to the IBM resources for the current spec
+type DryRunPlanStatus struct {
+	// ConfigMapName is the ConfigMap in the FusionAccess namespace that holds the per-object plan
+	ConfigMapName string `json:"configMapName"`
+	// ObservedGeneration is the generation of the FusionAccess spec the plan was computed for
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// Create is the number of objects that would be created
+	Create int32 `json:"create"`
+	// Update is the number of objects that would be updated
+	Update int32 `json:"update"`
+	// Prune is the number of objects that exist but are not part of the new manifest anymore
+	Prune int32 `json:"prune"`
+}
+
+// ManifestStatus tracks the IBM manifest applied by the operator
+type ManifestStatus struct {
+	// Hash is the content hash of the last manifest that was applied successfully. The manifest is
+	// only applied again when its content changes or when drift is corrected
+	Hash string `json:"hash"`
+	// LastDriftCheckTime is when the live IBM resources were last compared with the manifest
+	// +optional
+	LastDriftCheckTime *metav1.Time `json:"lastDriftCheckTime,omitempty"`
+	// DriftedResources lists the resources found to differ from the manifest at the last drift check
+	// +optional
+	DriftedResources []string `json:"driftedResources,omitempty"`
+}
+
+// CapacityStatus is the raw device capacity of the cluster
+type CapacityStatus struct {
+	DeviceCapacity `json:",inline"`
+	// Nodes is the capacity of each node with discovered or claimed devices
+	// +optional
+	Nodes []NodeCapacity `json:"nodes,omitempty"`
+}
+
+// NodeCapacity is the raw device capacity of a single node
+type NodeCapacity struct {
+	// NodeName is the name of the node the devices are attached to
+	NodeName       string `json:"nodeName"`
+	DeviceCapacity `json:",inline"`
+}
+
+// DeviceCapacity adds up the size of a set of devices. Discovered includes the claimed devices,
+// Available is what is discovered but not claimed yet and can be used for new filesystems
+type DeviceCapacity struct {
+	// Discovered is the size of all the devices
+	Discovered resource.Quantity `json:"discovered"`
+	// Claimed is the size of the devices used by IBM Storage Scale LocalDisks
+	Claimed resource.Quantity `json:"claimed"`
+	// Available is the size of the devices that are not claimed yet
+	Available resource.Quantity `json:"available"`
+	// DiscoveredDeviceCount is the number of devices
+	DiscoveredDeviceCount int32 `json:"discoveredDeviceCount"`
+	// ClaimedDeviceCount is the number of devices used by IBM Storage Scale LocalDisks
+	ClaimedDeviceCount int32 `json:"claimedDeviceCount"`
+	// AvailableDeviceCount is the number of devices that are not claimed yet
+	AvailableDeviceCount int32 `json:"availableDeviceCount"`
+}
+
+// ImagePullCheckStatus records the result of pulling an IBM image with the entitlement key
+type ImagePullCheckStatus struct {
+	// Image is the image reference that was pulled
+	Image string `json:"image"`
+	// ImageID is the digest the image resolved to. The result is only reused as long as the image
+	// resolves to the same digest
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
+	// SecretHash is the hash of the entitlement key used for the pull
+	SecretHash string `json:"secretHash"`
+	// Succeeded is true if the image could be pulled
+	Succeeded bool `json:"succeeded"`
+	// Message explains why the pull failed
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastCheckTime is when the check completed, or when the image was last found to resolve to the same
+	// digest. The registry is not asked again before the retry interval has passed
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+}
+
+// Condition types set on FusionAccess. Ready is derived from all the others
+const (
+	ConditionReady                = "Ready"
+	ConditionManifestApply        = "ManifestApply"
+	ConditionImagePull            = "ImagePull"
+	ConditionEntitlementSecrets   = "EntitlementSecrets"
+	ConditionGlobalPullSecret     = "GlobalPullSecret"
+	ConditionKernelModule         = "KernelModule"
+	ConditionConsolePlugin        = "ConsolePlugin"
+	ConditionDeviceDiscovery      = "DeviceDiscovery"
+	ConditionStorageScaleOperator = "StorageScaleOperator"
+	// ConditionManifestDrift is True when IBM resources differ from the applied manifest.
+	// It is informational and does not affect Ready
+	ConditionManifestDrift = "ManifestDrift"
+	// ConditionDryRun is True while the operator only plans changes instead of applying them
+	ConditionDryRun = "DryRun"
+	// ConditionExternalManifest reports whether a manifest that is not shipped with the operator, from
+	// spec.externalManifestURL or spec.manifestSource, could be loaded and verified
+	ConditionExternalManifest = "ExternalManifest"
+	// ConditionManifestPatches reports whether the patches from spec.manifestPatches are valid
+	ConditionManifestPatches = "ManifestPatches"
+	// ConditionPaused is True while reconciliation is suspended with the PausedAnnotation.
+	// It is informational and does not affect Ready
+	ConditionPaused = "Paused"
+	// ConditionUpgradeable is False when the current OpenShift version or one of its available updates is not
+	// supported by the installed Storage Scale release. It is also published to OLM to block OpenShift upgrades
+	ConditionUpgradeable = "Upgradeable"
+	// ConditionSupported is False when IBM does not support the Storage Scale version on the OpenShift version
+	// or on the architectures of the nodes. It is informational and does not affect Ready
+	ConditionSupported = "Supported"
+	// ConditionImageMirrors is False when the cluster mirrors images and some of the images the installation
+	// needs are not covered by an ImageDigestMirrorSet, ImageTagMirrorSet or ImageContentSourcePolicy.
+	// It is informational and does not affect Ready
+	ConditionImageMirrors = "ImageMirrors"
+	// ConditionStorageCluster mirrors the health of the IBM Storage Scale Cluster created from spec.storageCluster
+	ConditionStorageCluster = "StorageCluster"
+	// ConditionStorageNodes reports whether the nodes from spec.storageNodes carry the storage role label
+	ConditionStorageNodes = "StorageNodes"
+	// ConditionUpgrading is True while an installation is moved to a new spec.storageScaleVersion
+	ConditionUpgrading = "Upgrading"
+	// ConditionUninstalling reports the progress of the cleanup while the FusionAccess is being deleted
+	ConditionUninstalling = "Uninstalling"
+)
+
+// Reasons used by the FusionAccess conditions
+const (
+	// ReasonReconcileCompleted means the component was created or updated successfully
+	ReasonReconcileCompleted = "ReconcileCompleted"
+	// ReasonReconcileFailed means creating or updating the component failed, see the message for details
+	ReasonReconcileFailed = "ReconcileFailed"
+	// ReasonPullSecretMissing means the component needs the fusion-pullsecret secret which does not exist yet
+	ReasonPullSecretMissing = "PullSecretMissing"
+	// ReasonDisabled means the component was disabled in the spec
+	ReasonDisabled = "Disabled"
+	// ReasonNotApplicable means the component is not needed with the current spec
+	ReasonNotApplicable = "NotApplicable"
+	// ReasonImagePullDone means the IBM images could be pulled with the entitlement key
+	ReasonImagePullDone = "ImagePullDone"
+	// ReasonImagePullFailed means the IBM images could not be pulled with the entitlement key
+	ReasonImagePullFailed = "ImagePullFailed"
+	// ReasonImagePullInProgress means the pod verifying the image pull has not completed yet
+	ReasonImagePullInProgress = "ImagePullInProgress"
+	// ReasonDeploymentsAvailable means all the IBM operator deployments are rolled out and available
+	ReasonDeploymentsAvailable = "DeploymentsAvailable"
+	// ReasonDeploymentsProgressing means some IBM operator deployments are not available yet
+	ReasonDeploymentsProgressing = "DeploymentsProgressing"
+	// ReasonAllComponentsReady is the reason of the Ready condition when all the components are ready
+	ReasonAllComponentsReady = "AllComponentsReady"
+	// ReasonComponentsNotReady is the reason of the Ready condition when at least one component is not ready
+	ReasonComponentsNotReady = "ComponentsNotReady"
+	// ReasonNoDrift means all the IBM resources match the applied manifest
+	ReasonNoDrift = "NoDrift"
+	// ReasonDriftDetected means some IBM resources were changed or deleted, see the message for the list
+	ReasonDriftDetected = "DriftDetected"
+	// ReasonDriftCorrected means the manifest was re-applied to undo changes made to IBM resources
+	ReasonDriftCorrected = "DriftCorrected"
+	// ReasonPlanReady means the dry-run plan was written, see status.dryRunPlan
+	ReasonPlanReady = "PlanReady"
+	// ReasonPlanFailed means the dry-run plan could not be computed
+	ReasonPlanFailed = "PlanFailed"
+	// ReasonPatchesApplied means all the manifest patches were layered onto the IBM manifest
+	ReasonPatchesApplied = "PatchesApplied"
+	// ReasonPatchesInvalid means a manifest patch could not be read or applied, the manifest was not applied
+	ReasonPatchesInvalid = "PatchesInvalid"
+	// ReasonReconcilePaused means the operator does not change anything on the cluster and only reports status
+	ReasonReconcilePaused = "ReconcilePaused"
+	// ReasonReconcileResumed means the PausedAnnotation was removed and a full reconcile was run
+	ReasonReconcileResumed = "ReconcileResumed"
+	// ReasonOpenShiftVersionSupported means the current OpenShift version and its updates are supported
+	ReasonOpenShiftVersionSupported = "OpenShiftVersionSupported"
+	// ReasonOpenShiftVersionUnsupported means the current OpenShift version is not supported by Storage Scale
+	ReasonOpenShiftVersionUnsupported = "OpenShiftVersionUnsupported"
+	// ReasonOpenShiftUpdateUnsupported means an available OpenShift update is not supported by Storage Scale
+	ReasonOpenShiftUpdateUnsupported = "OpenShiftUpdateUnsupported"
+	// ReasonSupportedConfiguration means IBM supports the Storage Scale version on this cluster
+	ReasonSupportedConfiguration = "SupportedConfiguration"
+	// ReasonArchitectureUnsupported means some nodes have an architecture the Storage Scale version does not support
+	ReasonArchitectureUnsupported = "ArchitectureUnsupported"
+	// ReasonManifestFetched means the external manifest was downloaded and verified
+	ReasonManifestFetched = "ManifestFetched"
+	// ReasonCachedManifestUsed means the external manifest could not be loaded and the copy cached in the
+	// fusionaccess-manifest-cache ConfigMap was used
+	ReasonCachedManifestUsed = "CachedManifestUsed"
+	// ReasonManifestFetchFailed means the external manifest could not be downloaded and no cached copy exists
+	ReasonManifestFetchFailed = "ManifestFetchFailed"
+	// ReasonDigestMismatch means the content of the external manifest does not match spec.externalManifestDigest
+	ReasonDigestMismatch = "DigestMismatch"
+	// ReasonManifestLoaded means the manifest was loaded from spec.manifestSource
+	ReasonManifestLoaded = "ManifestLoaded"
+	// ReasonImagesMirrored means every required image is covered by a mirror
+	ReasonImagesMirrored = "ImagesMirrored"
+	// ReasonImagesNotMirrored means some required images are not covered by a mirror
+	ReasonImagesNotMirrored = "ImagesNotMirrored"
+	// ReasonManifestInvalid means the external manifest lacks the IBM operator configuration the operator relies on
+	ReasonManifestInvalid = "ManifestInvalid"
+	// ReasonStorageClusterHealthy means the IBM Storage Scale Cluster reports that it is healthy
+	ReasonStorageClusterHealthy = "StorageClusterHealthy"
+	// ReasonStorageClusterProgressing means the IBM Storage Scale Cluster was created but is not healthy yet
+	ReasonStorageClusterProgressing = "StorageClusterProgressing"
+	// ReasonStorageClusterUnhealthy means the IBM Storage Scale Cluster reports a failure, see the message for details
+	ReasonStorageClusterUnhealthy = "StorageClusterUnhealthy"
+	// ReasonStorageScaleOperatorUnavailable means the component waits for the IBM operator deployments
+	ReasonStorageScaleOperatorUnavailable = "StorageScaleOperatorUnavailable"
+	// ReasonNodesLabelled means exactly the selected nodes carry the storage role label
+	ReasonNodesLabelled = "NodesLabelled"
+	// ReasonNodesNotFound means some nodes listed in spec.storageNodes.nodes do not exist
+	ReasonNodesNotFound = "NodesNotFound"
+	// ReasonNodesInUse means nodes that are not selected anymore keep the storage role as they host LocalDisks
+	ReasonNodesInUse = "NodesInUse"
+	// ReasonUninstalling is the reason of the Ready condition while the FusionAccess object is being deleted
+	ReasonUninstalling = "Uninstalling"
+	// ReasonVersionInvalid means storageScaleVersion is not one of the releases shipped with the operator
+	ReasonVersionInvalid = "VersionInvalid"
+)
+
+// PausedAnnotation set to "true" on the FusionAccess object suspends reconciliation, e.g. during SAN
+// maintenance. The FusionAccess and LocalVolumeDiscovery controllers stop changing anything on the cluster
+// but keep reporting status
+const PausedAnnotation = "fusion.storage.openshift.io/paused"
+
+// AllowUnsupportedAnnotation set to "true" on the FusionAccess object lets the admission webhook accept a
+// Storage Scale version that IBM does not support on the cluster, e.g. to test upcoming OpenShift versions
+const AllowUnsupportedAnnotation = "fusion.storage.openshift.io/allow-unsupported"
+
+// StorageScaleVersionDefaultedAnnotation is set by the defaulting webhook when it picked storageScaleVersion,
+// its value explains the choice
+const StorageScaleVersionDefaultedAnnotation = "fusion.storage.openshift.io/storage-scale-version-defaulted"
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.installedStorageScaleVersion`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // FusionAccess is the Schema for the fusionaccesses API
 type FusionAccess struct {
@@ -72,6 +450,11 @@ type FusionAccess struct {
 	Status FusionAccessStatus `json:"status,omitempty"`
 }
 
+// IsPaused returns true if reconciliation is suspended with the PausedAnnotation
+func (f *FusionAccess) IsPaused() bool {
+	return f.Annotations[PausedAnnotation] == "true"
+}
+
 //+kubebuilder:object:root=true
 
 // FusionAccessList contains a list of FusionAccess
@@ -19,9 +19,11 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
@@ -51,7 +53,7 @@ type FusionAccessValidator struct {
 
 // FIXME(bandini): This needs to be reviewed more in detail. I added sideEffects=none to get it passing but not 100% sure about it
 //nolint:lll
-// +kubebuilder:webhook:verbs=create;update,path=/validate-fusion-storage-openshift-io-v1alpha1-fusionaccess,mutating=false,failurePolicy=fail,groups=fusion.storage.openshift.io,resources=fusionaccesses,versions=v1alpha1,name=fusion.storage.openshift.io,admissionReviewVersions=v1,sideEffects=none
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-fusion-storage-openshift-io-v1alpha1-fusionaccess,mutating=false,failurePolicy=fail,groups=fusion.storage.openshift.io,resources=fusionaccesses,versions=v1alpha1,name=fusion.storage.openshift.io,admissionReviewVersions=v1,sideEffects=none
 
 var _ webhook.CustomValidator = &FusionAccessValidator{}
 
@@ -66,9 +68,74 @@ func (r *FusionAccessValidator) SetupWebhookWithManager(mgr ctrl.Manager) error
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&FusionAccess{}).
 		WithValidator(r).
+		WithDefaulter(&FusionAccessDefaulter{Client: r.Client, configClient: r.configClient}).
 		Complete()
 }
 
+// +kubebuilder:object:generate=false
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+// FusionAccessDefaulter picks the Storage Scale version of a FusionAccess that is created without a version
+// and without an external manifest, e.g. from the OperatorHub form
+type FusionAccessDefaulter struct {
+	Client       client.Client
+	configClient configclient.Interface
+}
+
+//nolint:lll
+// +kubebuilder:webhook:verbs=create,path=/mutate-fusion-storage-openshift-io-v1alpha1-fusionaccess,mutating=true,failurePolicy=fail,groups=fusion.storage.openshift.io,resources=fusionaccesses,versions=v1alpha1,name=default.fusion.storage.openshift.io,admissionReviewVersions=v1,sideEffects=none
+
+var _ webhook.CustomDefaulter = &FusionAccessDefaulter{}
+
+// Default sets storageScaleVersion to the newest shipped release that supports the cluster. The choice is
+// explained in the StorageScaleVersionDefaultedAnnotation, which the validator returns as a warning
+func (d *FusionAccessDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	p, err := convertToFusionAccess(obj)
+	if err != nil {
+		return err
+	}
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation != admissionv1.Create {
+		return nil
+	}
+	if p.Spec.StorageScaleVersion != "" || p.Spec.ExternalManifestURL != "" || p.Spec.ManifestSource != nil {
+		return nil
+	}
+
+	clusterVersions, err := d.configClient.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list ClusterVersions: %v", err)
+	}
+	ocpVersion, err := utils.GetCurrentClusterVersion(clusterVersions)
+	if err != nil {
+		return fmt.Errorf("failed to get current cluster version: %v", err)
+	}
+	architectures, err := utils.GetNodeArchitectures(ctx, d.Client)
+	if err != nil {
+		return err
+	}
+	release, err := utils.NewestSupportedStorageScaleRelease(*ocpVersion, architectures)
+	if err != nil && p.Annotations[AllowUnsupportedAnnotation] == "true" {
+		// Unsupported combinations are allowed, take the newest release
+		releases, lerr := utils.GetStorageScaleReleases()
+		if lerr == nil && len(releases) > 0 {
+			release, err = releases[len(releases)-1], nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("storageScaleVersion cannot be defaulted, set it explicitly: %w", err)
+	}
+
+	p.Spec.StorageScaleVersion = StorageScaleVersions(release.Version)
+	if p.Annotations == nil {
+		p.Annotations = map[string]string{}
+	}
+	p.Annotations[StorageScaleVersionDefaultedAnnotation] = fmt.Sprintf(
+		"storageScaleVersion was set to %s, the newest IBM Storage Scale release supporting OpenShift %s (supported levels: %s)",
+		release.Version, ocpVersion, strings.Join(release.OpenShiftLevels, ", "))
+	fusionaccesslog.Info("default", "name", p.Name, "IBM Storage Scale Version", release.Version, "OCP Version", ocpVersion)
+	return nil
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *FusionAccessValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	p, err := convertToFusionAccess(obj)
@@ -85,29 +152,59 @@ func (r *FusionAccessValidator) ValidateCreate(ctx context.Context, obj runtime.
 	if len(fusionaccesses.Items) > 0 {
 		return nil, fmt.Errorf("only one FusionAccess resource is allowed")
 	}
+	if err := utils.ValidateStorageScaleVersion(string(p.Spec.StorageScaleVersion)); err != nil {
+		return nil, err
+	}
+	if err := validateExternalManifest(p.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateStorageNodes(p.Spec); err != nil {
+		return nil, err
+	}
 
-	clusterVersions, err := r.configClient.ConfigV1().ClusterVersions().Get(context.Background(), "version", metav1.GetOptions{})
+	warnings, err := r.validateSupport(ctx, p, string(p.Spec.StorageScaleVersion))
 	if err != nil {
-		return nil, fmt.Errorf("failed to list ClusterVersions: %v", err)
+		return nil, err
+	}
+	if reason, ok := p.Annotations[StorageScaleVersionDefaultedAnnotation]; ok {
+		warnings = append(warnings, reason)
 	}
+	fusionaccesslog.Info("validate create", "name", p.Name, "IBM Storage Scale Version", p.Spec.StorageScaleVersion)
+	return warnings, nil
+}
 
-	// Check if the IBM version we are running is an allowed one
+// validateSupport rejects a Storage Scale version that IBM does not support on this cluster, unless the
+// AllowUnsupportedAnnotation is set, in which case only a warning is returned
+func (r *FusionAccessValidator) validateSupport(ctx context.Context, p *FusionAccess, version string) (admission.Warnings, error) {
+	if version == "" {
+		return nil, nil
+	}
+	clusterVersions, err := r.configClient.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ClusterVersions: %v", err)
+	}
 	ocpVersion, err := utils.GetCurrentClusterVersion(clusterVersions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current cluster version: %v", err)
 	}
-	if !utils.IsOpenShiftSupported(string(p.Spec.StorageScaleVersion), *ocpVersion) {
-		// FIXME(bandini): we currently only log this so QE can test on upcoming versions that are not yet supported by IBM
-		fusionaccesslog.Info("IBM Storage Scale version not supported", "OCP Version", ocpVersion, "IBM Storage ScaleVersion", p.Spec.StorageScaleVersion)
-		// FIXME(bandini): return nil, fmt.Errorf("IBM Storage Scale version %s is not supported", ocpVersion)
-	} else {
-		fusionaccesslog.Info("validate create", "name", p.Name, "OCP Version", ocpVersion, "IBM Storage Scale Version", p.Spec.StorageScaleVersion)
+	architectures, err := utils.GetNodeArchitectures(ctx, r.Client)
+	if err != nil {
+		return nil, err
 	}
-	return nil, nil
+
+	err = utils.CheckStorageScaleSupport(version, *ocpVersion, architectures)
+	if err == nil {
+		return nil, nil
+	}
+	if p.Annotations[AllowUnsupportedAnnotation] == "true" {
+		fusionaccesslog.Info("IBM Storage Scale version not supported, allowed by annotation", "name", p.Name, "reason", err.Error())
+		return admission.Warnings{fmt.Sprintf("%v. Allowed by the %s annotation", err, AllowUnsupportedAnnotation)}, nil
+	}
+	return nil, fmt.Errorf("%w. Set the %s annotation to \"true\" to install it anyway", err, AllowUnsupportedAnnotation)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *FusionAccessValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (r *FusionAccessValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	p, err := convertToFusionAccess(oldObj)
 	if err != nil {
 		fusionaccesslog.Error(err, "validate update", "name", p.Name)
@@ -119,12 +216,6 @@ func (r *FusionAccessValidator) ValidateUpdate(_ context.Context, oldObj, newObj
 		return nil, err
 	}
 
-	// FIXME(bandini): IBM CNSA version cannot be updated for now
-	// FIXME(bandini): Maybe here we could introduce code to double check which upgrades paths we allow
-	// but for now we just log things
-	// if pNew.Spec.IbmCnsaVersion != p.Spec.IbmCnsaVersion {
-	// 	return nil, fmt.Errorf("IBM CNSA version cannot be updated")
-	// }
 	fusionaccesslog.Info(
 		"validate update",
 		"name",
@@ -135,12 +226,42 @@ func (r *FusionAccessValidator) ValidateUpdate(_ context.Context, oldObj, newObj
 		p.Spec.StorageScaleVersion,
 	)
 
-	return nil, nil
+	if err := validateExternalManifest(pNew.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateStorageNodes(pNew.Spec); err != nil {
+		return nil, err
+	}
+
+	var warnings admission.Warnings
+	if pNew.Spec.StorageScaleVersion != p.Spec.StorageScaleVersion {
+		if err := utils.ValidateStorageScaleVersion(string(pNew.Spec.StorageScaleVersion)); err != nil {
+			return nil, err
+		}
+		if warnings, err = r.validateSupport(ctx, pNew, string(pNew.Spec.StorageScaleVersion)); err != nil {
+			return nil, err
+		}
+	}
+
+	// An upgrade is checked against what is actually rolled out, which may lag behind the old spec
+	// while a previous upgrade is still in progress
+	from := p.Status.InstalledStorageScaleVersion
+	if from == "" {
+		from = string(p.Spec.StorageScaleVersion)
+	}
+	to := string(pNew.Spec.StorageScaleVersion)
+	if from != "" && to != "" && from != to {
+		if err := utils.IsStorageScaleUpgradeAllowed(from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *FusionAccessValidator) ValidateDelete(
-	_ context.Context,
+	ctx context.Context,
 	obj runtime.Object,
 ) (admission.Warnings, error) {
 	p, err := convertToFusionAccess(obj)
@@ -150,9 +271,54 @@ func (r *FusionAccessValidator) ValidateDelete(
 	}
 	fusionaccesslog.Info("validate delete", "name", p.Name)
 
+	// Removing FusionAccess uninstalls the IBM operator, which must not happen while filesystems exist
+	filesystems, err := utils.ListIBMFilesystems(ctx, r.Client)
+	if err != nil {
+		return nil, err
+	}
+	if len(filesystems) > 0 {
+		return nil, fmt.Errorf("FusionAccess cannot be deleted while IBM filesystems exist: %s", strings.Join(filesystems, ", "))
+	}
+
 	return nil, nil
 }
 
+// validateExternalManifest makes sure an external manifest comes from an allowed location, that a digest
+// is only set together with the URL it verifies and that a single manifest source is set
+func validateExternalManifest(spec FusionAccessSpec) error {
+	if spec.ExternalManifestDigest != "" && spec.ExternalManifestURL == "" {
+		return fmt.Errorf("externalManifestDigest can only be set together with externalManifestURL")
+	}
+	if source := spec.ManifestSource; source != nil {
+		if spec.ExternalManifestURL != "" {
+			return fmt.Errorf("manifestSource cannot be combined with externalManifestURL")
+		}
+		if (len(source.ConfigMaps) > 0) == (source.OCIArtifact != nil) {
+			return fmt.Errorf("manifestSource must set exactly one of configMaps and ociArtifact")
+		}
+	}
+	if spec.ExternalManifestURL != "" && !utils.IsExternalManifestURLAllowed(spec.ExternalManifestURL) {
+		return fmt.Errorf("externalManifestURL %s does not start with one of the allowed prefixes: %s",
+			spec.ExternalManifestURL, strings.Join(utils.GetExternalManifestAllowedPrefixes(), ", "))
+	}
+	return nil
+}
+
+// validateStorageNodes makes sure the storage nodes are selected in exactly one way
+func validateStorageNodes(spec FusionAccessSpec) error {
+	nodes := spec.StorageNodes
+	if nodes == nil {
+		return nil
+	}
+	if (nodes.NodeSelector != nil) == (len(nodes.Nodes) > 0) {
+		return fmt.Errorf("storageNodes must set exactly one of nodeSelector and nodes")
+	}
+	if nodes.NodeSelector != nil && len(nodes.NodeSelector.NodeSelectorTerms) == 0 {
+		return fmt.Errorf("storageNodes.nodeSelector must have at least one term")
+	}
+	return nil
+}
+
 func convertToFusionAccess(obj runtime.Object) (*FusionAccess, error) {
 	p, ok := obj.(*FusionAccess)
 	if !ok {
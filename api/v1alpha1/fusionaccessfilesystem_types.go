@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FusionAccessFileSystemSpec defines the desired state of FusionAccessFileSystem
+// +kubebuilder:validation:XValidation:rule="!has(self.wipeExistingData) || self.wipeExistingData.all(w, w in self.disks)",message="wipeExistingData can only list disks of the filesystem"
+type FusionAccessFileSystemSpec struct {
+	// Disks are the WWNs of the shared LUNs the filesystem is made of, as reported by the device discovery.
+	// Disks can be added to grow the filesystem, but not removed
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:XValidation:rule="oldSelf.all(d, d in self)",message="disks can only be added"
+	// +listType=set
+	Disks []string `json:"disks"`
+	// Replication is the number of copies of each data and metadata block. It cannot be changed once set
+	// +kubebuilder:validation:Enum="1-way";"2-way";"3-way"
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="replication cannot be changed once set"
+	// +kubebuilder:default:="1-way"
+	// +optional
+	Replication string `json:"replication,omitempty"`
+	// WipeExistingData lists the WWNs from spec.disks whose existing data may be destroyed. The disks on which
+	// the device discovery finds partition tables, filesystems, LVM or GPFS NSD descriptors are only used
+	// once they are listed here
+	// +listType=set
+	// +optional
+	WipeExistingData []string `json:"wipeExistingData,omitempty"`
+}
+
+// FileSystemDiskPhase is the progress of a disk of a FusionAccessFileSystem
+type FileSystemDiskPhase string
+
+const (
+	// DiskPending means the LocalDisk was not created yet. The message says if the device discovery did not
+	// find the WWN
+	DiskPending FileSystemDiskPhase = "Pending"
+	// DiskNotShared means the WWN was not found on all the storage nodes by the device discovery
+	DiskNotShared FileSystemDiskPhase = "NotShared"
+	// DiskDataFound means the device holds existing data and is not listed in spec.wipeExistingData
+	DiskDataFound FileSystemDiskPhase = "DataFound"
+	// DiskCreated means the LocalDisk was created
+	DiskCreated FileSystemDiskPhase = "Created"
+	// DiskInUse means IBM Storage Scale added the LocalDisk to the filesystem
+	DiskInUse FileSystemDiskPhase = "InUse"
+)
+
+// FileSystemDiskStatus is the progress of a disk of a FusionAccessFileSystem
+type FileSystemDiskStatus struct {
+	// WWN is the WWN of the LUN from spec.disks
+	WWN string `json:"wwn"`
+	// Node is the node the LocalDisk is created on
+	// +optional
+	Node string `json:"node,omitempty"`
+	// Device is the path of the LUN on the node
+	// +optional
+	Device string `json:"device,omitempty"`
+	// Size is the size of the LUN in bytes, as reported by the device discovery
+	// +optional
+	Size int64 `json:"size,omitempty"`
+	// LocalDisk is the name of the IBM LocalDisk in the ibm-spectrum-scale namespace
+	// +optional
+	LocalDisk string `json:"localDisk,omitempty"`
+	// ExistingData lists the signatures the device discovery found on the LUN before it was used
+	// +optional
+	ExistingData []string `json:"existingData,omitempty"`
+	// Phase is the progress of the disk
+	Phase FileSystemDiskPhase `json:"phase"`
+	// Message explains why the disk cannot be used yet
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FusionAccessFileSystemStatus defines the observed state of FusionAccessFileSystem
+type FusionAccessFileSystemStatus struct {
+	// Conditions is a list of conditions and their status.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the last generation change the operator has dealt with
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Disks is the progress of each disk from spec.disks
+	// +optional
+	Disks []FileSystemDiskStatus `json:"disks,omitempty"`
+	// StorageClassName is the StorageClass provisioning volumes on the filesystem
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// Capacity is the raw size of the disks Storage Scale added to the filesystem, before replication
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+}
+
+// Condition types set on FusionAccessFileSystem
+const (
+	// FileSystemConditionResourcesCreated is True once the LocalDisks, the Filesystem and the StorageClass
+	// were all created. Until then a failure removes whatever was created
+	FileSystemConditionResourcesCreated = "ResourcesCreated"
+	// FileSystemConditionDisksAdded is True once the LocalDisks of all the disks in spec.disks were created
+	// and added to the Filesystem. It is only set once the filesystem was created
+	FileSystemConditionDisksAdded = "DisksAdded"
+	// FileSystemConditionReady is True when the IBM Filesystem reports that it is healthy
+	FileSystemConditionReady = "Ready"
+)
+
+// Reasons used by the FusionAccessFileSystem conditions
+const (
+	// ReasonDisksNotDiscovered means some WWNs were not found by the device discovery, nothing was created
+	ReasonDisksNotDiscovered = "DisksNotDiscovered"
+	// ReasonDisksNotShared means some WWNs were not found on all the storage nodes
+	ReasonDisksNotShared = "DisksNotShared"
+	// ReasonDisksAdded means the LocalDisks of all the disks were added to the Filesystem
+	ReasonDisksAdded = "DisksAdded"
+	// ReasonDisksContainData means some disks hold existing data that was not acknowledged to be destroyed,
+	// nothing was created
+	ReasonDisksContainData = "DisksContainData"
+	// ReasonUnsupportedNamespace means the FusionAccessFileSystem is outside the ibm-spectrum-scale namespace,
+	// nothing was created
+	ReasonUnsupportedNamespace = "UnsupportedNamespace"
+	// ReasonResourcesCreated means the LocalDisks, the Filesystem and the StorageClass exist
+	ReasonResourcesCreated = "ResourcesCreated"
+	// ReasonCreateFailed means a resource could not be created and the ones created before were removed
+	ReasonCreateFailed = "CreateFailed"
+	// ReasonResourcesTerminating means a resource left over from a failed attempt is still being deleted, it is
+	// created again once it is gone
+	ReasonResourcesTerminating = "ResourcesTerminating"
+	// ReasonFileSystemProgressing means the IBM Filesystem was created but is not healthy yet
+	ReasonFileSystemProgressing = "FileSystemProgressing"
+	// ReasonFileSystemHealthy means the IBM Filesystem reports that it is healthy
+	ReasonFileSystemHealthy = "FileSystemHealthy"
+	// ReasonFileSystemUnhealthy means the IBM Filesystem reports a failure, see the message for details
+	ReasonFileSystemUnhealthy = "FileSystemUnhealthy"
+	// ReasonVolumesExist means the deletion waits for the persistent volumes of the StorageClass to be removed
+	ReasonVolumesExist = "VolumesExist"
+	// ReasonDeleting means the StorageClass, the Filesystem and the LocalDisks are being removed
+	ReasonDeleting = "Deleting"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +kubebuilder:resource:path=fusionaccessfilesystems,scope=Namespaced
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="StorageClass",type=string,JSONPath=`.status.storageClassName`
+// +kubebuilder:printcolumn:name="Capacity",type=string,JSONPath=`.status.capacity`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FusionAccessFileSystem is an IBM Storage Scale filesystem on shared LUNs. The operator creates a LocalDisk
+// for each LUN, the Filesystem and a StorageClass of the same name, and removes them all when it is deleted.
+// The StorageClass is cluster-scoped, so FusionAccessFileSystems are only handled in the ibm-spectrum-scale
+// namespace, where their names are unique
+type FusionAccessFileSystem struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FusionAccessFileSystemSpec   `json:"spec,omitempty"`
+	Status FusionAccessFileSystemStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FusionAccessFileSystemList contains a list of FusionAccessFileSystem
+type FusionAccessFileSystemList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FusionAccessFileSystem `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FusionAccessFileSystem{}, &FusionAccessFileSystemList{})
+}
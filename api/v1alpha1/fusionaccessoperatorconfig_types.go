@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FusionAccessOperatorConfigName is the name of the only FusionAccessOperatorConfig the operator reads
+const FusionAccessOperatorConfigName = "cluster"
+
+// OperatorSettings are the settings of the operator that can be changed while it runs
+type OperatorSettings struct {
+	// DeviceFinderImage is the image of the device discovery daemons.
+	// Defaults to the RELATED_IMAGE_OPENSHIFT-STORAGE-SCALE-OPERATOR-DEVICEFINDER variable of the operator
+	// +optional
+	DeviceFinderImage string `json:"deviceFinderImage,omitempty"`
+	// KubeRBACProxyImage is the image of the proxy protecting the metrics of the device discovery daemons.
+	// Defaults to the KUBE_RBAC_PROXY_IMAGE variable of the operator
+	// +optional
+	KubeRBACProxyImage string `json:"kubeRBACProxyImage,omitempty"`
+	// PriorityClassName is the priority class of the device discovery daemons.
+	// Defaults to the PRIORITY_CLASS_NAME variable of the operator
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// IBMRegistry is the registry the IBM entitlement key is used for. Defaults to cp.icr.io
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]([-a-zA-Z0-9.]*[a-zA-Z0-9])?(:[0-9]+)?$`
+	// +optional
+	IBMRegistry string `json:"ibmRegistry,omitempty"`
+	// IBMRegistryUser is the user the IBM entitlement key is used with. Defaults to cp
+	// +optional
+	IBMRegistryUser string `json:"ibmRegistryUser,omitempty"`
+	// EntitlementNamespaces are the namespaces the IBM entitlement key is copied to, besides the namespace
+	// of the operator. Defaults to the namespaces of the IBM Storage Scale components
+	// +listType=set
+	// +kubebuilder:validation:items:MaxLength=63
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	EntitlementNamespaces []string `json:"entitlementNamespaces,omitempty"`
+}
+
+// FusionAccessOperatorConfigStatus reports the settings the operator uses
+type FusionAccessOperatorConfigStatus struct {
+	// ObservedGeneration is the generation of the spec the effective settings were computed from
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Effective are the settings in use: the spec, and the environment of the operator or the built-in
+	// defaults where the spec does not set a value
+	// +optional
+	Effective OperatorSettings `json:"effective,omitempty"`
+	// DeploymentNamespace is the namespace the operator runs in. It is set by the DEPLOYMENT_NAMESPACE
+	// variable and cannot be changed here
+	// +optional
+	DeploymentNamespace string `json:"deploymentNamespace,omitempty"`
+	// WebhooksEnabled is false when the operator was started with ENABLE_WEBHOOKS=false. It cannot be
+	// changed here as the webhooks are only registered when the operator starts
+	WebhooksEnabled bool `json:"webhooksEnabled"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +kubebuilder:resource:path=fusionaccessoperatorconfigs,scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'cluster'",message="the FusionAccessOperatorConfig must be named cluster"
+// +kubebuilder:printcolumn:name="Device finder",type=string,JSONPath=`.status.effective.deviceFinderImage`
+// +kubebuilder:printcolumn:name="Registry",type=string,JSONPath=`.status.effective.ibmRegistry`
+
+// FusionAccessOperatorConfig configures the operator. It is a singleton named cluster, which the operator
+// creates with an empty spec when it starts. Settings left empty keep the values from the environment of
+// the operator, so changing behaviour does not require editing the ClusterServiceVersion
+type FusionAccessOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OperatorSettings                 `json:"spec,omitempty"`
+	Status FusionAccessOperatorConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FusionAccessOperatorConfigList contains a list of FusionAccessOperatorConfig
+type FusionAccessOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FusionAccessOperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FusionAccessOperatorConfig{}, &FusionAccessOperatorConfigList{})
+}
@@ -36,6 +36,10 @@ type DiscoveredDevice struct {
 	Size int64 `json:"size"`
 	// WWN defines the WWN value of the device.
 	WWN string `json:"WWN"`
+	// ExistingData lists the partition tables, filesystems, LVM, RAID or GPFS NSD signatures found on the
+	// device, or "unverified" if it could not be checked. It is empty when the device looks blank
+	// +optional
+	ExistingData []string `json:"existingData,omitempty"`
 }
 
 // LocalVolumeDiscoveryResultSpec defines the desired state of LocalVolumeDiscoveryResult
@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StorageScaleReleaseSpec describes an IBM Storage Scale container native release shipped with the operator
+type StorageScaleReleaseSpec struct {
+	// Version is the value to use in the storageScaleVersion field of FusionAccess
+	Version string `json:"version"`
+	// CSIVersion is the version of the IBM Storage Scale CSI driver shipped with this release
+	// +optional
+	CSIVersion string `json:"csiVersion,omitempty"`
+	// Architectures lists the CPU architectures supported by this release
+	// +optional
+	Architectures []string `json:"architectures,omitempty"`
+	// OpenShiftLevels lists the OpenShift minor versions supported by this release
+	// +optional
+	OpenShiftLevels []string `json:"openshiftLevels,omitempty"`
+	// RemoteStorageClusterLevel is the minimum level of a remote storage cluster
+	// +optional
+	RemoteStorageClusterLevel string `json:"remoteStorageClusterLevel,omitempty"`
+	// FileSystemVersion is the file system format version of this release
+	// +optional
+	FileSystemVersion string `json:"fileSystemVersion,omitempty"`
+	// UpgradeFrom lists the versions that can be upgraded in place to this release
+	// +optional
+	UpgradeFrom []string `json:"upgradeFrom,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +kubebuilder:resource:path=storagescalereleases,scope=Cluster
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`
+// +kubebuilder:printcolumn:name="CSI",type=string,JSONPath=`.spec.csiVersion`
+// +kubebuilder:printcolumn:name="OpenShift",type=string,JSONPath=`.spec.openshiftLevels`
+
+// StorageScaleRelease is a read-only catalog entry of an IBM Storage Scale release the operator can install.
+// The objects are created from the metadata shipped with the operator and any change to them is reverted
+type StorageScaleRelease struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec StorageScaleReleaseSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// StorageScaleReleaseList contains a list of StorageScaleRelease
+type StorageScaleReleaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StorageScaleRelease `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StorageScaleRelease{}, &StorageScaleReleaseList{})
+}
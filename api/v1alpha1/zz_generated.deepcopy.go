@@ -26,9 +26,37 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityStatus) DeepCopyInto(out *CapacityStatus) {
+	*out = *in
+	in.DeviceCapacity.DeepCopyInto(&out.DeviceCapacity)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeCapacity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityStatus.
+func (in *CapacityStatus) DeepCopy() *CapacityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiscoveredDevice) DeepCopyInto(out *DiscoveredDevice) {
 	*out = *in
+	if in.ExistingData != nil {
+		in, out := &in.ExistingData, &out.ExistingData
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredDevice.
@@ -41,12 +69,65 @@ func (in *DiscoveredDevice) DeepCopy() *DiscoveredDevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceCapacity) DeepCopyInto(out *DeviceCapacity) {
+	*out = *in
+	out.Discovered = in.Discovered.DeepCopy()
+	out.Claimed = in.Claimed.DeepCopy()
+	out.Available = in.Available.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceCapacity.
+func (in *DeviceCapacity) DeepCopy() *DeviceCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunPlanStatus) DeepCopyInto(out *DryRunPlanStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunPlanStatus.
+func (in *DryRunPlanStatus) DeepCopy() *DryRunPlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunPlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSystemDiskStatus) DeepCopyInto(out *FileSystemDiskStatus) {
+	*out = *in
+	if in.ExistingData != nil {
+		in, out := &in.ExistingData, &out.ExistingData
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemDiskStatus.
+func (in *FileSystemDiskStatus) DeepCopy() *FileSystemDiskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FileSystemDiskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FusionAccess) DeepCopyInto(out *FusionAccess) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -68,6 +149,124 @@ func (in *FusionAccess) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FusionAccessFileSystem) DeepCopyInto(out *FusionAccessFileSystem) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessFileSystem.
+func (in *FusionAccessFileSystem) DeepCopy() *FusionAccessFileSystem {
+	if in == nil {
+		return nil
+	}
+	out := new(FusionAccessFileSystem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FusionAccessFileSystem) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FusionAccessFileSystemList) DeepCopyInto(out *FusionAccessFileSystemList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FusionAccessFileSystem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessFileSystemList.
+func (in *FusionAccessFileSystemList) DeepCopy() *FusionAccessFileSystemList {
+	if in == nil {
+		return nil
+	}
+	out := new(FusionAccessFileSystemList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FusionAccessFileSystemList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FusionAccessFileSystemSpec) DeepCopyInto(out *FusionAccessFileSystemSpec) {
+	*out = *in
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WipeExistingData != nil {
+		in, out := &in.WipeExistingData, &out.WipeExistingData
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessFileSystemSpec.
+func (in *FusionAccessFileSystemSpec) DeepCopy() *FusionAccessFileSystemSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FusionAccessFileSystemSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FusionAccessFileSystemStatus) DeepCopyInto(out *FusionAccessFileSystemStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]FileSystemDiskStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessFileSystemStatus.
+func (in *FusionAccessFileSystemStatus) DeepCopy() *FusionAccessFileSystemStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FusionAccessFileSystemStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FusionAccessList) DeepCopyInto(out *FusionAccessList) {
 	*out = *in
@@ -100,10 +299,105 @@ func (in *FusionAccessList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FusionAccessOperatorConfig) DeepCopyInto(out *FusionAccessOperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessOperatorConfig.
+func (in *FusionAccessOperatorConfig) DeepCopy() *FusionAccessOperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FusionAccessOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FusionAccessOperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FusionAccessOperatorConfigList) DeepCopyInto(out *FusionAccessOperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FusionAccessOperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessOperatorConfigList.
+func (in *FusionAccessOperatorConfigList) DeepCopy() *FusionAccessOperatorConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(FusionAccessOperatorConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FusionAccessOperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FusionAccessOperatorConfigStatus) DeepCopyInto(out *FusionAccessOperatorConfigStatus) {
+	*out = *in
+	in.Effective.DeepCopyInto(&out.Effective)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessOperatorConfigStatus.
+func (in *FusionAccessOperatorConfigStatus) DeepCopy() *FusionAccessOperatorConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FusionAccessOperatorConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FusionAccessSpec) DeepCopyInto(out *FusionAccessSpec) {
 	*out = *in
 	out.LocalVolumeDiscovery = in.LocalVolumeDiscovery
+	if in.ManifestPatches != nil {
+		in, out := &in.ManifestPatches, &out.ManifestPatches
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManifestSource != nil {
+		in, out := &in.ManifestSource, &out.ManifestSource
+		*out = new(ManifestSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageCluster != nil {
+		in, out := &in.StorageCluster, &out.StorageCluster
+		*out = new(StorageClusterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageNodes != nil {
+		in, out := &in.StorageNodes, &out.StorageNodes
+		*out = new(StorageNodesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessSpec.
@@ -131,6 +425,31 @@ func (in *FusionAccessStatus) DeepCopyInto(out *FusionAccessStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ImagePullCheck != nil {
+		in, out := &in.ImagePullCheck, &out.ImagePullCheck
+		*out = new(ImagePullCheckStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(CapacityStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Manifest != nil {
+		in, out := &in.Manifest, &out.Manifest
+		*out = new(ManifestStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DryRunPlan != nil {
+		in, out := &in.DryRunPlan, &out.DryRunPlan
+		*out = new(DryRunPlanStatus)
+		**out = **in
+	}
+	if in.StorageNodes != nil {
+		in, out := &in.StorageNodes, &out.StorageNodes
+		*out = new(StorageNodesStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessStatus.
@@ -143,6 +462,22 @@ func (in *FusionAccessStatus) DeepCopy() *FusionAccessStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullCheckStatus) DeepCopyInto(out *ImagePullCheckStatus) {
+	*out = *in
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullCheckStatus.
+func (in *ImagePullCheckStatus) DeepCopy() *ImagePullCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalVolumeDiscovery) DeepCopyInto(out *LocalVolumeDiscovery) {
 	*out = *in
@@ -282,7 +617,9 @@ func (in *LocalVolumeDiscoveryResultStatus) DeepCopyInto(out *LocalVolumeDiscove
 	if in.DiscoveredDevices != nil {
 		in, out := &in.DiscoveredDevices, &out.DiscoveredDevices
 		*out = make([]DiscoveredDevice, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -345,6 +682,168 @@ func (in *LocalVolumeDiscoveryStatus) DeepCopy() *LocalVolumeDiscoveryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestSource) DeepCopyInto(out *ManifestSource) {
+	*out = *in
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.OCIArtifact != nil {
+		in, out := &in.OCIArtifact, &out.OCIArtifact
+		*out = new(OCIArtifactSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestSource.
+func (in *ManifestSource) DeepCopy() *ManifestSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestStatus) DeepCopyInto(out *ManifestStatus) {
+	*out = *in
+	if in.LastDriftCheckTime != nil {
+		in, out := &in.LastDriftCheckTime, &out.LastDriftCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DriftedResources != nil {
+		in, out := &in.DriftedResources, &out.DriftedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestStatus.
+func (in *ManifestStatus) DeepCopy() *ManifestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeCapacity) DeepCopyInto(out *NodeCapacity) {
+	*out = *in
+	in.DeviceCapacity.DeepCopyInto(&out.DeviceCapacity)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCapacity.
+func (in *NodeCapacity) DeepCopy() *NodeCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIArtifactSource) DeepCopyInto(out *OCIArtifactSource) {
+	*out = *in
+	if in.PullSecret != nil {
+		in, out := &in.PullSecret, &out.PullSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIArtifactSource.
+func (in *OCIArtifactSource) DeepCopy() *OCIArtifactSource {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIArtifactSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorSettings) DeepCopyInto(out *OperatorSettings) {
+	*out = *in
+	if in.EntitlementNamespaces != nil {
+		in, out := &in.EntitlementNamespaces, &out.EntitlementNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorSettings.
+func (in *OperatorSettings) DeepCopy() *OperatorSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClusterLicense) DeepCopyInto(out *StorageClusterLicense) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClusterLicense.
+func (in *StorageClusterLicense) DeepCopy() *StorageClusterLicense {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClusterLicense)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClusterSpec) DeepCopyInto(out *StorageClusterSpec) {
+	*out = *in
+	out.License = in.License
+	if in.DaemonNodeSelector != nil {
+		in, out := &in.DaemonNodeSelector, &out.DaemonNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PMCollectorNodeSelector != nil {
+		in, out := &in.PMCollectorNodeSelector, &out.PMCollectorNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ClusterProfile != nil {
+		in, out := &in.ClusterProfile, &out.ClusterProfile
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClusterSpec.
+func (in *StorageClusterSpec) DeepCopy() *StorageClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageDeviceDiscovery) DeepCopyInto(out *StorageDeviceDiscovery) {
 	*out = *in
@@ -359,3 +858,141 @@ func (in *StorageDeviceDiscovery) DeepCopy() *StorageDeviceDiscovery {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageNodesSpec) DeepCopyInto(out *StorageNodesSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(corev1.NodeSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageNodesSpec.
+func (in *StorageNodesSpec) DeepCopy() *StorageNodesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageNodesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageNodesStatus) DeepCopyInto(out *StorageNodesStatus) {
+	*out = *in
+	if in.Selected != nil {
+		in, out := &in.Selected, &out.Selected
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retained != nil {
+		in, out := &in.Retained, &out.Retained
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageNodesStatus.
+func (in *StorageNodesStatus) DeepCopy() *StorageNodesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageNodesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageScaleRelease) DeepCopyInto(out *StorageScaleRelease) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageScaleRelease.
+func (in *StorageScaleRelease) DeepCopy() *StorageScaleRelease {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageScaleRelease)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageScaleRelease) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageScaleReleaseList) DeepCopyInto(out *StorageScaleReleaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StorageScaleRelease, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageScaleReleaseList.
+func (in *StorageScaleReleaseList) DeepCopy() *StorageScaleReleaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageScaleReleaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageScaleReleaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageScaleReleaseSpec) DeepCopyInto(out *StorageScaleReleaseSpec) {
+	*out = *in
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OpenShiftLevels != nil {
+		in, out := &in.OpenShiftLevels, &out.OpenShiftLevels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpgradeFrom != nil {
+		in, out := &in.UpgradeFrom, &out.UpgradeFrom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageScaleReleaseSpec.
+func (in *StorageScaleReleaseSpec) DeepCopy() *StorageScaleReleaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageScaleReleaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
@@ -33,6 +34,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -40,13 +42,16 @@ import (
 
 	kmmv1beta1 "github.com/rh-ecosystem-edge/kernel-module-management/api/v1beta1"
 
+	configv1 "github.com/openshift/api/config/v1"
 	consolev1 "github.com/openshift/api/console/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
 
 	lvdcontroller "github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/localvolumediscovery"
 
 	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/version"
 	//+kubebuilder:scaffold:imports
 )
@@ -67,6 +72,10 @@ func init() {
 
 	utilruntime.Must(operatorv1.AddToScheme(scheme))
 
+	utilruntime.Must(operatorv1alpha1.AddToScheme(scheme))
+
+	utilruntime.Must(configv1.AddToScheme(scheme))
+
 	utilruntime.Must(kmmv1beta1.AddToScheme(scheme))
 
 	//+kubebuilder:scaffold:scheme
@@ -96,6 +105,15 @@ func main() {
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
 	printVersion()
+	releases, err := utils.GetStorageScaleReleases()
+	if err != nil {
+		setupLog.Error(err, "unable to load the Storage Scale release metadata")
+		os.Exit(1)
+	}
+	for _, release := range releases {
+		setupLog.Info(fmt.Sprintf("Storage Scale release available: %s", release.Version))
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -156,7 +174,34 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "FusionAccess")
 		os.Exit(1)
 	}
-	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+	if err = (&controller.FusionAccessOperatorConfigReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FusionAccessOperatorConfig")
+		os.Exit(1)
+	}
+	if err = (&controller.FusionAccessFileSystemReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FusionAccessFileSystem")
+		os.Exit(1)
+	}
+	if err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		return controller.EnsureOperatorConfig(ctx, mgr.GetClient())
+	})); err != nil {
+		setupLog.Error(err, "unable to set up the operator configuration")
+		os.Exit(1)
+	}
+	// Publish the Storage Scale release catalog shipped with this operator
+	if err = (&controller.StorageScaleReleaseReconciler{
+		Client: mgr.GetClient(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "StorageScaleRelease")
+		os.Exit(1)
+	}
+	if utils.WebhooksEnabled() {
 		if err = (&fusionv1alpha.FusionAccessValidator{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "FusionAccess")
 			os.Exit(1)
@@ -12,7 +12,11 @@ import {
 
 import { useFusionAccessTranslations } from "@/hooks/useFusionAccessTranslations";
 import type { FileSystem } from "@/models/ibm-spectrum-scale/FileSystem";
-import { FS_ALLOW_DELETE_LABEL } from "@/constants";
+import {
+  FS_ALLOW_DELETE_LABEL,
+  OWNER_NAME_LABEL,
+  OWNER_NAMESPACE_LABEL,
+} from "@/constants";
 import { FileSystemTableContext } from "@/contexts/filesystemctx";
 import { getFileSystemScs } from "@/utils/filesystem";
 
@@ -33,6 +37,12 @@ const DeleteFilesystemModal: React.FC<DeleteFilesystemModalProps> = ({
     kind: "Filesystem",
   });
 
+  const [fusionAccessFileSystemModel] = useK8sModel({
+    group: "fusion.storage.openshift.io",
+    version: "v1alpha1",
+    kind: "FusionAccessFileSystem",
+  });
+
   const [localDiskModel] = useK8sModel({
     group: "scale.spectrum.ibm.com",
     version: "v1beta1",
@@ -59,6 +69,23 @@ const DeleteFilesystemModal: React.FC<DeleteFilesystemModalProps> = ({
     setIsDeleting(true);
     setErrors(undefined);
     try {
+      // Filesystems created through a FusionAccessFileSystem are removed by
+      // the operator, which waits for their volumes and then deletes the
+      // StorageClass, the Filesystem and the LocalDisks
+      const ownerName = filesystem.metadata?.labels?.[OWNER_NAME_LABEL];
+      const ownerNamespace = filesystem.metadata?.labels?.[OWNER_NAMESPACE_LABEL];
+      if (ownerName && ownerNamespace) {
+        await k8sDelete({
+          model: fusionAccessFileSystemModel,
+          ns: ownerNamespace,
+          resource: {
+            metadata: { name: ownerName, namespace: ownerNamespace },
+          },
+        });
+        onClose();
+        return;
+      }
+
       if (filesystem.metadata?.labels?.[FS_ALLOW_DELETE_LABEL] == undefined) {
         await k8sPatch({
           model: fileSystemModel,
@@ -0,0 +1,31 @@
+import type { K8sResourceKind } from "@openshift-console/dynamic-plugin-sdk";
+
+export interface FusionAccessFileSystem extends K8sResourceKind {
+  spec: {
+    disks: string[];
+    replication?: "1-way" | "2-way" | "3-way";
+    wipeExistingData?: string[];
+  };
+  status?: {
+    conditions?: Array<{
+      lastTransitionTime: string;
+      message: string;
+      reason: string;
+      status: "True" | "False" | "Unknown";
+      type: string;
+    }>;
+    disks?: Array<{
+      wwn: string;
+      node?: string;
+      device?: string;
+      size?: number;
+      localDisk?: string;
+      existingData?: string[];
+      phase: "Pending" | "NotShared" | "DataFound" | "Created" | "InUse";
+      message?: string;
+    }>;
+    observedGeneration?: number;
+    storageClassName?: string;
+    capacity?: string;
+  };
+}
@@ -13,6 +13,7 @@ export interface LocalVolumeDiscoveryResult extends K8sResourceKind {
 export interface DiscoveredDevice {
   WWN: string;
   deviceID: string;
+  existingData?: string[];
   model: string;
   path: string;
   size: number;
@@ -95,12 +95,15 @@ const FileSystemCreateForm = () => {
 
   const selectedLuns = useSelectedLuns(getValue("selected-luns"));
 
-  // we show only disks that are present in all nodes
+  // we show only disks that are present in all nodes and hold no data, the
+  // operator refuses the others unless their data loss is acknowledged
   const discoveredDevices =
     discoveryResultsForStorageNodes[0]?.status?.discoveredDevices?.filter(
       ({ WWN }) =>
         discoveryResultsForStorageNodes.every((r) =>
-          r.status?.discoveredDevices?.some((d) => d.WWN === WWN)
+          r.status?.discoveredDevices?.some(
+            (d) => d.WWN === WWN && !d.existingData?.length
+          )
         )
     ) || [];
 
@@ -109,7 +112,6 @@ const FileSystemCreateForm = () => {
   );
   const handleCreateFileSystem = useCreateFileSystemHandler(
     fileSystemName,
-    discoveryResultsForStorageNodes,
     selectedDevices
   );
 
@@ -23,10 +23,19 @@ const (
 	DiscoveryNodeLabel = "discovery-result-node"
 
 	DeviceFinderDiscoveryDaemonSetTemplate = "templates/devicefinder-discovery-daemonset.yaml"
+
+	// StorageRoleLabel is the label of the nodes that run IBM Storage Scale, the console labels the nodes it
+	// is asked to use with it
+	StorageRoleLabel = "scale.spectrum.ibm.com/role"
+	// StorageRoleValue is the value of StorageRoleLabel on the storage nodes
+	StorageRoleValue = "storage"
 )
 
 // GetDeviceFinderImage returns the image to be used for devicefinder daemonset
 func GetDeviceFinderImage() string {
+	if image := getSettings().DeviceFinderImage; image != "" {
+		return image
+	}
 	if deviceFinderImageFromEnv := os.Getenv(DeviceFinderImageEnv); deviceFinderImageFromEnv != "" {
 		return deviceFinderImageFromEnv
 	}
@@ -35,6 +44,9 @@ func GetDeviceFinderImage() string {
 
 // GetKubeRBACProxyImage returns the image to be used for Kube RBAC Proxy sidecar container
 func GetKubeRBACProxyImage() string {
+	if image := getSettings().KubeRBACProxyImage; image != "" {
+		return image
+	}
 	if kubeRBACProxyImageFromEnv := os.Getenv(KubeRBACProxyImageEnv); kubeRBACProxyImageFromEnv != "" {
 		return kubeRBACProxyImageFromEnv
 	}
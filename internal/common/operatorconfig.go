@@ -0,0 +1,110 @@
+package common
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sync"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+const (
+	// PriorityClassNameEnv is used by the operator to read the priority class of the discovery daemons
+	PriorityClassNameEnv = "PRIORITY_CLASS_NAME"
+
+	defaultIBMRegistry     = "cp.icr.io"
+	defaultIBMRegistryUser = "cp"
+)
+
+// defaultEntitlementNamespaces are the namespaces of the IBM Storage Scale components that pull from the
+// IBM registry
+var defaultEntitlementNamespaces = []string{
+	"ibm-spectrum-scale",
+	"ibm-spectrum-scale-dns",
+	"ibm-spectrum-scale-csi",
+	"ibm-spectrum-scale-operator",
+}
+
+var (
+	settingsLock sync.RWMutex
+	settings     fusionv1alpha1.OperatorSettings
+)
+
+// SetOperatorSettings replaces the settings read from the FusionAccessOperatorConfig
+func SetOperatorSettings(spec fusionv1alpha1.OperatorSettings) {
+	settingsLock.Lock()
+	defer settingsLock.Unlock()
+	settings = *spec.DeepCopy()
+}
+
+func getSettings() fusionv1alpha1.OperatorSettings {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+	return *settings.DeepCopy()
+}
+
+// LoadOperatorConfig reads the FusionAccessOperatorConfig singleton so that the getters of this package
+// return its values. A missing object leaves every setting to the environment or the built-in default
+func LoadOperatorConfig(ctx context.Context, cl client.Reader) error {
+	config := &fusionv1alpha1.FusionAccessOperatorConfig{}
+	err := cl.Get(ctx, types.NamespacedName{Name: fusionv1alpha1.FusionAccessOperatorConfigName}, config)
+	if kerrors.IsNotFound(err) {
+		SetOperatorSettings(fusionv1alpha1.OperatorSettings{})
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	SetOperatorSettings(config.Spec)
+	return nil
+}
+
+// EffectiveOperatorSettings returns the value of every setting in use
+func EffectiveOperatorSettings() fusionv1alpha1.OperatorSettings {
+	return fusionv1alpha1.OperatorSettings{
+		DeviceFinderImage:     GetDeviceFinderImage(),
+		KubeRBACProxyImage:    GetKubeRBACProxyImage(),
+		PriorityClassName:     GetPriorityClassName(),
+		IBMRegistry:           GetIBMRegistry(),
+		IBMRegistryUser:       GetIBMRegistryUser(),
+		EntitlementNamespaces: GetEntitlementNamespaces(),
+	}
+}
+
+// GetPriorityClassName returns the priority class of the devicefinder daemonset, empty for none
+func GetPriorityClassName() string {
+	if name := getSettings().PriorityClassName; name != "" {
+		return name
+	}
+	return os.Getenv(PriorityClassNameEnv)
+}
+
+// GetIBMRegistry returns the registry the IBM entitlement key is used for
+func GetIBMRegistry() string {
+	if registry := getSettings().IBMRegistry; registry != "" {
+		return registry
+	}
+	return defaultIBMRegistry
+}
+
+// GetIBMRegistryUser returns the user the IBM entitlement key is used with
+func GetIBMRegistryUser() string {
+	if user := getSettings().IBMRegistryUser; user != "" {
+		return user
+	}
+	return defaultIBMRegistryUser
+}
+
+// GetEntitlementNamespaces returns the namespaces the IBM entitlement key is copied to, besides the
+// namespace of the operator
+func GetEntitlementNamespaces() []string {
+	if namespaces := getSettings().EntitlementNamespaces; len(namespaces) > 0 {
+		return namespaces
+	}
+	return slices.Clone(defaultEntitlementNamespaces)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+// refreshCapacity is how often the capacity is recomputed once everything is ready, as changes
+// to the IBM LocalDisks are not watched
+var refreshCapacity = ctrl.Result{RequeueAfter: 5 * time.Minute}
+
+// capacityDevice is a device found by the device discovery or claimed by a LocalDisk
+type capacityDevice struct {
+	size    int64
+	claimed bool
+}
+
+// reconcileCapacity sets the capacity status from the LocalVolumeDiscoveryResults in our namespace
+// and the IBM LocalDisks in the cluster
+func (r *FusionAccessReconciler) reconcileCapacity(ctx context.Context, ns string, fusionaccess *fusionv1alpha1.FusionAccess) error {
+	results := &fusionv1alpha1.LocalVolumeDiscoveryResultList{}
+	if err := r.List(ctx, results, client.InNamespace(ns)); err != nil {
+		return fmt.Errorf("failed to list the device discovery results: %w", err)
+	}
+	localDisks, err := utils.ListIBMLocalDisks(ctx, r.Client)
+	if err != nil {
+		return err
+	}
+
+	capacity := computeCapacity(results.Items, localDisks)
+	fusionaccess.Status.Capacity = capacity
+	fusionaccess.Status.TotalProvisionedDeviceCount = &capacity.ClaimedDeviceCount
+	return nil
+}
+
+// computeCapacity adds up the size of the devices for each node. A LocalDisk is matched to a discovered
+// device on the same node by its path or its persistent name. LocalDisks whose device was not discovered
+// are still counted as discovered and claimed with the size reported by IBM Storage Scale.
+// A shared LUN is discovered on every node it is attached to: it is counted once in the cluster totals
+// and it is claimed on all the nodes as soon as a LocalDisk uses it on one of them
+func computeCapacity(
+	results []fusionv1alpha1.LocalVolumeDiscoveryResult,
+	localDisks []unstructured.Unstructured,
+) *fusionv1alpha1.CapacityStatus {
+	// node name -> device path or persistent name -> device
+	nodes := map[string]map[string]*capacityDevice{}
+	devices := map[string][]*capacityDevice{}
+	// WWN -> device shared by all the nodes that see it
+	luns := map[string]*capacityDevice{}
+	for _, result := range results {
+		node := result.Spec.NodeName
+		if nodes[node] == nil {
+			nodes[node] = map[string]*capacityDevice{}
+		}
+		for _, discovered := range result.Status.DiscoveredDevices {
+			device := luns[discovered.WWN]
+			if device == nil {
+				device = &capacityDevice{size: discovered.Size}
+				if discovered.WWN != "" {
+					luns[discovered.WWN] = device
+				}
+			}
+			nodes[node][discovered.Path] = device
+			if discovered.DeviceID != "" {
+				nodes[node][discovered.DeviceID] = device
+			}
+			devices[node] = append(devices[node], device)
+		}
+	}
+
+	for _, localDisk := range localDisks {
+		node, _, _ := unstructured.NestedString(localDisk.Object, "spec", "node")
+		path, _, _ := unstructured.NestedString(localDisk.Object, "spec", "device")
+		if device, ok := nodes[node][path]; ok {
+			device.claimed = true
+			continue
+		}
+		size, _, _ := unstructured.NestedString(localDisk.Object, "status", "size")
+		quantity, err := parseLocalDiskSize(size)
+		if err != nil {
+			log.Log.Info(fmt.Sprintf("Ignoring the size of LocalDisk %s/%s: %v", localDisk.GetNamespace(), localDisk.GetName(), err))
+		}
+		devices[node] = append(devices[node], &capacityDevice{size: quantity.Value(), claimed: true})
+	}
+
+	capacity := &fusionv1alpha1.CapacityStatus{DeviceCapacity: newDeviceCapacity()}
+	nodeNames := make([]string, 0, len(devices))
+	for node := range devices {
+		nodeNames = append(nodeNames, node)
+	}
+	sort.Strings(nodeNames)
+	counted := map[*capacityDevice]bool{}
+	for _, node := range nodeNames {
+		nodeCapacity := fusionv1alpha1.NodeCapacity{NodeName: node, DeviceCapacity: newDeviceCapacity()}
+		for _, device := range devices[node] {
+			addDevice(&nodeCapacity.DeviceCapacity, device)
+			if !counted[device] {
+				counted[device] = true
+				addDevice(&capacity.DeviceCapacity, device)
+			}
+		}
+		capacity.Nodes = append(capacity.Nodes, nodeCapacity)
+	}
+	return capacity
+}
+
+func newDeviceCapacity() fusionv1alpha1.DeviceCapacity {
+	return fusionv1alpha1.DeviceCapacity{
+		Discovered: *resource.NewQuantity(0, resource.BinarySI),
+		Claimed:    *resource.NewQuantity(0, resource.BinarySI),
+		Available:  *resource.NewQuantity(0, resource.BinarySI),
+	}
+}
+
+func addDevice(capacity *fusionv1alpha1.DeviceCapacity, device *capacityDevice) {
+	size := *resource.NewQuantity(device.size, resource.BinarySI)
+	capacity.Discovered.Add(size)
+	capacity.DiscoveredDeviceCount++
+	if device.claimed {
+		capacity.Claimed.Add(size)
+		capacity.ClaimedDeviceCount++
+		return
+	}
+	capacity.Available.Add(size)
+	capacity.AvailableDeviceCount++
+}
+
+// localDiskSizeUnits maps the uppercased units a LocalDisk may report its size in to quantity suffixes
+var localDiskSizeUnits = map[string]string{
+	"B":   "",
+	"KB":  "k",
+	"MB":  "M",
+	"GB":  "G",
+	"TB":  "T",
+	"PB":  "P",
+	"EB":  "E",
+	"KIB": "Ki",
+	"MIB": "Mi",
+	"GIB": "Gi",
+	"TIB": "Ti",
+	"PIB": "Pi",
+	"EIB": "Ei",
+}
+
+// parseLocalDiskSize parses the size reported in the LocalDisk status, which may be
+// written either as a quantity ("100Gi") or with a unit ("100 GiB")
+func parseLocalDiskSize(size string) (resource.Quantity, error) {
+	size = strings.ReplaceAll(size, " ", "")
+	if size == "" {
+		return resource.Quantity{}, fmt.Errorf("no size reported yet")
+	}
+	number, unit := size, ""
+	if i := strings.IndexFunc(size, unicode.IsLetter); i >= 0 {
+		number, unit = size[:i], size[i:]
+	}
+	if suffix, ok := localDiskSizeUnits[strings.ToUpper(unit)]; ok {
+		unit = suffix
+	}
+	return resource.ParseQuantity(number + unit)
+}
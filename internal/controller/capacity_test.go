@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+const gib = int64(1024 * 1024 * 1024)
+
+func newDiscoveryResult(node string, devices ...fusionv1alpha.DiscoveredDevice) *fusionv1alpha.LocalVolumeDiscoveryResult {
+	return &fusionv1alpha.LocalVolumeDiscoveryResult{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery-result-" + node, Namespace: "ibm-fusion-access-operator"},
+		Spec:       fusionv1alpha.LocalVolumeDiscoveryResultSpec{NodeName: node},
+		Status:     fusionv1alpha.LocalVolumeDiscoveryResultStatus{DiscoveredDevices: devices},
+	}
+}
+
+func newLocalDisk(name, node, device, size string) *unstructured.Unstructured {
+	localDisk := &unstructured.Unstructured{}
+	localDisk.SetGroupVersionKind(utils.IBMLocalDiskGVK)
+	localDisk.SetName(name)
+	localDisk.SetNamespace("ibm-spectrum-scale")
+	Expect(unstructured.SetNestedField(localDisk.Object, node, "spec", "node")).To(Succeed())
+	Expect(unstructured.SetNestedField(localDisk.Object, device, "spec", "device")).To(Succeed())
+	if size != "" {
+		Expect(unstructured.SetNestedField(localDisk.Object, size, "status", "size")).To(Succeed())
+	}
+	return localDisk
+}
+
+var _ = Describe("Capacity accounting", func() {
+	var (
+		worker0 = newDiscoveryResult("worker-0",
+			fusionv1alpha.DiscoveredDevice{DeviceID: "/dev/disk/by-id/wwn-0x1", Path: "/dev/sdb", Size: 100 * gib},
+			fusionv1alpha.DiscoveredDevice{DeviceID: "/dev/disk/by-id/wwn-0x2", Path: "/dev/sdc", Size: 50 * gib},
+		)
+		worker1 = newDiscoveryResult("worker-1",
+			fusionv1alpha.DiscoveredDevice{DeviceID: "/dev/disk/by-id/wwn-0x3", Path: "/dev/sdb", Size: 200 * gib},
+		)
+	)
+
+	Describe("computeCapacity", func() {
+		It("reports everything as available when nothing is claimed", func() {
+			capacity := computeCapacity([]fusionv1alpha.LocalVolumeDiscoveryResult{*worker0, *worker1}, nil)
+			Expect(capacity.Discovered.Value()).To(Equal(350 * gib))
+			Expect(capacity.Available.Value()).To(Equal(350 * gib))
+			Expect(capacity.Claimed.Value()).To(BeZero())
+			Expect(capacity.DiscoveredDeviceCount).To(Equal(int32(3)))
+			Expect(capacity.Nodes).To(HaveLen(2))
+			Expect(capacity.Nodes[0].NodeName).To(Equal("worker-0"))
+			Expect(capacity.Nodes[0].Discovered.Value()).To(Equal(150 * gib))
+		})
+
+		It("matches LocalDisks to discovered devices by path or persistent name", func() {
+			localDisks := []unstructured.Unstructured{
+				*newLocalDisk("disk1", "worker-0", "/dev/disk/by-id/wwn-0x1", "100Gi"),
+				*newLocalDisk("disk2", "worker-1", "/dev/sdb", "200Gi"),
+			}
+			capacity := computeCapacity([]fusionv1alpha.LocalVolumeDiscoveryResult{*worker0, *worker1}, localDisks)
+			Expect(capacity.Discovered.Value()).To(Equal(350 * gib))
+			Expect(capacity.Claimed.Value()).To(Equal(300 * gib))
+			Expect(capacity.Available.Value()).To(Equal(50 * gib))
+			Expect(capacity.ClaimedDeviceCount).To(Equal(int32(2)))
+			Expect(capacity.AvailableDeviceCount).To(Equal(int32(1)))
+			Expect(capacity.Nodes[1].Available.Value()).To(BeZero())
+		})
+
+		It("counts a shared LUN once and claims it on every node", func() {
+			lun := fusionv1alpha.DiscoveredDevice{DeviceID: "/dev/disk/by-id/wwn-0x9", Path: "/dev/sdd", Size: 500 * gib, WWN: "0x9"}
+			shared0 := newDiscoveryResult("worker-0", lun)
+			lun.Path = "/dev/sde"
+			shared1 := newDiscoveryResult("worker-1", lun)
+			localDisks := []unstructured.Unstructured{
+				*newLocalDisk("lun", "worker-0", "/dev/sdd", "500Gi"),
+			}
+			capacity := computeCapacity([]fusionv1alpha.LocalVolumeDiscoveryResult{*shared0, *shared1}, localDisks)
+			Expect(capacity.Discovered.Value()).To(Equal(500 * gib))
+			Expect(capacity.DiscoveredDeviceCount).To(Equal(int32(1)))
+			Expect(capacity.Claimed.Value()).To(Equal(500 * gib))
+			Expect(capacity.ClaimedDeviceCount).To(Equal(int32(1)))
+			Expect(capacity.Available.Value()).To(BeZero())
+			Expect(capacity.Nodes).To(HaveLen(2))
+			Expect(capacity.Nodes[1].Discovered.Value()).To(Equal(500 * gib))
+			Expect(capacity.Nodes[1].Claimed.Value()).To(Equal(500 * gib))
+			Expect(capacity.Nodes[1].Available.Value()).To(BeZero())
+		})
+
+		It("uses the LocalDisk size for devices that were not discovered", func() {
+			localDisks := []unstructured.Unstructured{
+				*newLocalDisk("disk1", "worker-2", "/dev/nvme0n1", "10 GiB"),
+				*newLocalDisk("disk2", "worker-2", "/dev/nvme1n1", ""),
+			}
+			capacity := computeCapacity(nil, localDisks)
+			Expect(capacity.Discovered.Value()).To(Equal(10 * gib))
+			Expect(capacity.Claimed.Value()).To(Equal(10 * gib))
+			Expect(capacity.ClaimedDeviceCount).To(Equal(int32(2)))
+			Expect(capacity.Nodes).To(HaveLen(1))
+			Expect(capacity.Nodes[0].NodeName).To(Equal("worker-2"))
+		})
+	})
+
+	DescribeTable("parseLocalDiskSize",
+		func(size string, expected int64) {
+			quantity, err := parseLocalDiskSize(size)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(quantity.Value()).To(Equal(expected))
+		},
+		Entry("bytes", "512 B", int64(512)),
+		Entry("kilobytes", "2 KB", int64(2000)),
+		Entry("megabytes", "2 MB", int64(2000*1000)),
+		Entry("gigabytes", "2 GB", int64(2000*1000*1000)),
+		Entry("terabytes", "2 TB", int64(2000*1000*1000*1000)),
+		Entry("petabytes", "2 PB", int64(2000*1000*1000*1000*1000)),
+		Entry("kibibytes", "2 KiB", int64(2*1024)),
+		Entry("mebibytes", "2 MiB", int64(2*1024*1024)),
+		Entry("gibibytes", "2 GiB", 2*gib),
+		Entry("tebibytes", "2 TiB", 2*1024*gib),
+		Entry("pebibytes", "2 PiB", 2*1024*1024*gib),
+		Entry("a fractional size", "1.5 GiB", 3*gib/2),
+		Entry("a quantity", "100Gi", 100*gib),
+		Entry("a quantity without unit", "4096", int64(4096)),
+	)
+
+	It("does not parse a size that was not reported yet or has an unknown unit", func() {
+		_, err := parseLocalDiskSize("")
+		Expect(err).To(HaveOccurred())
+		_, err = parseLocalDiskSize("10 parsecs")
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("reconcileCapacity", func() {
+		It("sets the capacity and the provisioned device count in the status", func() {
+			r := newFakeReconciler([]client.Object{worker0, newLocalDisk("disk1", "worker-0", "/dev/sdc", "50Gi")})
+			fusionaccess := &fusionv1alpha.FusionAccess{}
+
+			Expect(r.reconcileCapacity(context.Background(), "ibm-fusion-access-operator", fusionaccess)).To(Succeed())
+			Expect(fusionaccess.Status.Capacity).ToNot(BeNil())
+			Expect(fusionaccess.Status.Capacity.Claimed.Value()).To(Equal(50 * gib))
+			Expect(fusionaccess.Status.Capacity.Available.Value()).To(Equal(100 * gib))
+			Expect(fusionaccess.Status.TotalProvisionedDeviceCount).ToNot(BeNil())
+			Expect(*fusionaccess.Status.TotalProvisionedDeviceCount).To(Equal(int32(1)))
+		})
+	})
+})
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+// waitForComponents is how often we check again if the components that are still rolling out became ready
+var waitForComponents = ctrl.Result{RequeueAfter: 30 * time.Second}
+
+// readinessConditions are the component conditions that all need to be True for FusionAccess to be Ready
+var readinessConditions = []string{
+	fusionv1alpha1.ConditionExternalManifest,
+	fusionv1alpha1.ConditionManifestPatches,
+	fusionv1alpha1.ConditionManifestApply,
+	fusionv1alpha1.ConditionStorageScaleOperator,
+	fusionv1alpha1.ConditionEntitlementSecrets,
+	fusionv1alpha1.ConditionGlobalPullSecret,
+	fusionv1alpha1.ConditionKernelModule,
+	fusionv1alpha1.ConditionImagePull,
+	fusionv1alpha1.ConditionConsolePlugin,
+	fusionv1alpha1.ConditionDeviceDiscovery,
+	fusionv1alpha1.ConditionStorageNodes,
+	fusionv1alpha1.ConditionStorageCluster,
+}
+
+// setCondition sets a condition for the current generation of the FusionAccess object
+func setCondition(fusionaccess *fusionv1alpha1.FusionAccess, condType string, status v1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&fusionaccess.Status.Conditions, v1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: fusionaccess.Generation,
+	})
+}
+
+// setReadyCondition derives the Ready condition from the component conditions. A component that did
+// not report anything yet counts as not ready
+func setReadyCondition(fusionaccess *fusionv1alpha1.FusionAccess) {
+	notReady := []string{}
+	for _, condType := range readinessConditions {
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, condType)
+		switch {
+		case cond == nil:
+			notReady = append(notReady, fmt.Sprintf("%s (Unknown)", condType))
+		case cond.Status != v1.ConditionTrue:
+			notReady = append(notReady, fmt.Sprintf("%s (%s)", condType, cond.Reason))
+		}
+	}
+
+	if len(notReady) == 0 {
+		fusionaccess.Status.Status = "Ready"
+		setCondition(fusionaccess, fusionv1alpha1.ConditionReady, v1.ConditionTrue,
+			fusionv1alpha1.ReasonAllComponentsReady, "All components are ready")
+		return
+	}
+	if fusionaccess.Status.Status == "Ready" || fusionaccess.Status.Status == "" {
+		fusionaccess.Status.Status = "NotReady"
+	}
+	setCondition(fusionaccess, fusionv1alpha1.ConditionReady, v1.ConditionFalse,
+		fusionv1alpha1.ReasonComponentsNotReady, fmt.Sprintf("Components not ready: %s", strings.Join(notReady, ", ")))
+}
+
+// updateStatus recomputes Ready and writes the status of the FusionAccess object
+func (r *FusionAccessReconciler) updateStatus(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) error {
+	setReadyCondition(fusionaccess)
+	fusionaccess.Status.ObservedGeneration = fusionaccess.Generation
+	return r.Status().Update(ctx, fusionaccess)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("setReadyCondition", func() {
+	var fusionaccess *fusionv1alpha.FusionAccess
+
+	BeforeEach(func() {
+		fusionaccess = &fusionv1alpha.FusionAccess{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+		for _, condType := range readinessConditions {
+			setCondition(fusionaccess, condType, metav1.ConditionTrue, fusionv1alpha.ReasonReconcileCompleted, "")
+		}
+	})
+
+	It("is Ready when all the components are ready", func() {
+		setReadyCondition(fusionaccess)
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionReady)
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonAllComponentsReady))
+		Expect(cond.ObservedGeneration).To(Equal(int64(3)))
+		Expect(fusionaccess.Status.Status).To(Equal("Ready"))
+	})
+
+	It("is not Ready when a component is not ready", func() {
+		fusionaccess.Status.Status = "Ready"
+		setCondition(fusionaccess, fusionv1alpha.ConditionKernelModule, metav1.ConditionFalse,
+			fusionv1alpha.ReasonPullSecretMissing, "")
+		setReadyCondition(fusionaccess)
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionReady)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonComponentsNotReady))
+		Expect(cond.Message).To(ContainSubstring("KernelModule (PullSecretMissing)"))
+		Expect(fusionaccess.Status.Status).To(Equal("NotReady"))
+	})
+
+	It("is not Ready when a component did not report yet", func() {
+		meta.RemoveStatusCondition(&fusionaccess.Status.Conditions, fusionv1alpha.ConditionStorageScaleOperator)
+		fusionaccess.Status.Status = "ErrImagePull"
+		setReadyCondition(fusionaccess)
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionReady)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("StorageScaleOperator (Unknown)"))
+		Expect(fusionaccess.Status.Status).To(Equal("ErrImagePull"))
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
 	"github.com/pkg/errors"
 
@@ -46,23 +47,27 @@ const (
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=consoles,verbs=get;list;watch;update
 
 // CreateOrUpdatePlugin creates or updates the resources needed for the remediation console plugin.
+// The ConsolePlugin is cluster-scoped, it is only labelled with owner.
 // HEADS UP: consider cleanup of old resources in case of name changes or removals!
-func CreateOrUpdatePlugin(ctx context.Context, cl client.Client) error {
+func CreateOrUpdatePlugin(ctx context.Context, cl client.Client, owner client.Object) error {
 	// Create ConsolePlugin resource
 	// Deployment and Service are deployed by OLM
 	ns, err := utils.GetDeploymentNamespace()
 	if err != nil {
 		return err
 	}
-	if err := createOrUpdateConsolePlugin(ctx, ns, cl); err != nil {
+	if err := createOrUpdateConsolePlugin(ctx, ns, cl, owner); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func createOrUpdateConsolePlugin(ctx context.Context, namespace string, cl client.Client) error {
+func createOrUpdateConsolePlugin(ctx context.Context, namespace string, cl client.Client, owner client.Object) error {
 	cp := newConsolePlugin(namespace)
+	if err := kubeutils.SetOwner(owner, cp, cl.Scheme()); err != nil {
+		return errors.Wrap(err, "could not set the owner of the console plugin")
+	}
 	oldCP := &consolev1.ConsolePlugin{}
 	if err := cl.Get(ctx, client.ObjectKeyFromObject(cp), oldCP); apierrors.IsNotFound(err) {
 		if err := cl.Create(ctx, cp); err != nil {
@@ -71,7 +76,7 @@ func createOrUpdateConsolePlugin(ctx context.Context, namespace string, cl clien
 	} else if err != nil {
 		return errors.Wrap(err, "could not check for existing console plugin")
 	} else {
-		oldCP.OwnerReferences = cp.OwnerReferences
+		kubeutils.CopyOwner(oldCP, cp)
 		oldCP.Spec = cp.Spec
 		if err := cl.Update(ctx, oldCP); err != nil {
 			return errors.Wrap(err, "could not update console plugin")
@@ -84,8 +89,6 @@ func newConsolePlugin(namespace string) *consolev1.ConsolePlugin {
 	return &consolev1.ConsolePlugin{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: PluginName,
-			// TODO set owner ref for deletion when operator is uninstalled
-			// but which resource to use, needs to be cluster scoped
 		},
 		Spec: consolev1.ConsolePluginSpec{
 			DisplayName: "Fusion Access for SAN plugin",
@@ -120,3 +123,35 @@ func EnablePlugin(ctx context.Context, cl client.Client) error {
 	}
 	return nil
 }
+
+// DisablePlugin removes our plugin from the list of enabled plugins in the cluster console
+func DisablePlugin(ctx context.Context, cl client.Client) error {
+	consoleKey := client.ObjectKey{Namespace: "", Name: "cluster"}
+	consoleObj := &operatorv1.Console{}
+	if err := cl.Get(ctx, consoleKey, consoleObj); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Could not find resource - APIVersion: %s, Kind: %s, Name: %s",
+			consoleObj.APIVersion, consoleObj.Kind, consoleObj.Name))
+	}
+
+	if idx := slices.Index(consoleObj.Spec.Plugins, PluginName); idx != -1 {
+		consoleObj.Spec.Plugins = slices.Delete(consoleObj.Spec.Plugins, idx, idx+1)
+		err := cl.Update(ctx, consoleObj)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Could not update resource - APIVersion: %s, Kind: %s, Name: %s",
+				consoleObj.APIVersion, consoleObj.Kind, consoleObj.Name))
+		}
+	}
+	return nil
+}
+
+// DeletePlugin deletes the ConsolePlugin resource created by CreateOrUpdatePlugin
+func DeletePlugin(ctx context.Context, cl client.Client) error {
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return err
+	}
+	if err := cl.Delete(ctx, newConsolePlugin(ns)); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "could not delete console plugin")
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/manifestival/manifestival"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+const (
+	// dryRunAnnotation set to "true" on the FusionAccess object makes the operator only compute what it
+	// would change for the current spec, without changing anything on the cluster
+	dryRunAnnotation = "fusion.storage.openshift.io/dry-run"
+	// dryRunPlanConfigMap holds the plan, it is owned by the FusionAccess object
+	dryRunPlanConfigMap = "fusionaccess-dry-run-plan"
+)
+
+// The actions of a dry-run plan entry
+const (
+	planCreate    = "create"
+	planUpdate    = "update"
+	planPrune     = "prune"
+	planUnchanged = "unchanged"
+)
+
+// planEntry is what would happen to a single object of the manifest
+type planEntry struct {
+	Action   string `yaml:"action"`
+	Resource string `yaml:"resource"`
+	// Changes are the paths of the fields that would be changed by an update
+	Changes []string `yaml:"changes,omitempty"`
+	// Error is set when the API server rejected the dry-run request
+	Error string `yaml:"error,omitempty"`
+}
+
+func isDryRun(fusionaccess *fusionv1alpha1.FusionAccess) bool {
+	return fusionaccess.Annotations[dryRunAnnotation] == "true"
+}
+
+// reconcileDryRun writes the plan for the current spec to a ConfigMap. The plan is only computed again
+// when the spec changes or when dry-run is enabled again
+func (r *FusionAccessReconciler) reconcileDryRun(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	installManifest manifestival.Manifest,
+) error {
+	if plan := fusionaccess.Status.DryRunPlan; plan != nil && plan.ObservedGeneration == fusionaccess.Generation {
+		return nil
+	}
+
+	log.Log.Info("Dry-run is enabled, computing the plan instead of applying the manifest")
+	entries, err := r.planManifest(ctx, fusionaccess, installManifest)
+	if err == nil {
+		err = r.writeDryRunPlan(ctx, fusionaccess, entries)
+	}
+	if err != nil {
+		setCondition(fusionaccess, fusionv1alpha1.ConditionDryRun, v1.ConditionTrue,
+			fusionv1alpha1.ReasonPlanFailed, fmt.Sprintf("The dry-run plan could not be computed: %v", err))
+		if serr := r.updateStatus(ctx, fusionaccess); serr != nil {
+			return errors.Join(serr, err)
+		}
+		return err
+	}
+
+	status := &fusionv1alpha1.DryRunPlanStatus{
+		ConfigMapName:      dryRunPlanConfigMap,
+		ObservedGeneration: fusionaccess.Generation,
+	}
+	for _, entry := range entries {
+		switch entry.Action {
+		case planCreate:
+			status.Create++
+		case planUpdate:
+			status.Update++
+		case planPrune:
+			status.Prune++
+		}
+	}
+	fusionaccess.Status.DryRunPlan = status
+	setCondition(fusionaccess, fusionv1alpha1.ConditionDryRun, v1.ConditionTrue, fusionv1alpha1.ReasonPlanReady,
+		fmt.Sprintf("Nothing was changed, %d objects would be created, %d updated and %d pruned. See ConfigMap %s",
+			status.Create, status.Update, status.Prune, dryRunPlanConfigMap))
+	return r.updateStatus(ctx, fusionaccess)
+}
+
+// clearDryRun drops the plan from the status once dry-run is disabled
+func clearDryRun(fusionaccess *fusionv1alpha1.FusionAccess) {
+	fusionaccess.Status.DryRunPlan = nil
+	meta.RemoveStatusCondition(&fusionaccess.Status.Conditions, fusionv1alpha1.ConditionDryRun)
+}
+
+// planManifest compares the manifest with the live objects and validates every create and update
+// with a server-side dry-run request. Objects of the installed version's manifest that are not part
+// of the new one are reported as pruned
+func (r *FusionAccessReconciler) planManifest(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	installManifest manifestival.Manifest,
+) ([]planEntry, error) {
+	// Objects in namespaces that are created by the same manifest cannot be validated before the namespace exists
+	newNamespaces := map[string]bool{}
+	for _, res := range installManifest.Filter(manifestival.ByKind("Namespace")).Resources() {
+		live, err := r.getLiveObject(ctx, &res)
+		if err != nil {
+			return nil, err
+		}
+		newNamespaces[res.GetName()] = live == nil
+	}
+
+	entries := []planEntry{}
+	planned := map[string]bool{}
+	for _, desired := range installManifest.Resources() {
+		entry := planEntry{Resource: resourceID(&desired)}
+		planned[entry.Resource] = true
+
+		live, err := r.getLiveObject(ctx, &desired)
+		if err != nil {
+			return nil, err
+		}
+		if live == nil {
+			entry.Action = planCreate
+			err := r.Create(ctx, desired.DeepCopy(), client.DryRunAll)
+			if err != nil && !meta.IsNoMatchError(err) && !(kerrors.IsNotFound(err) && newNamespaces[desired.GetNamespace()]) {
+				entry.Error = err.Error()
+			}
+			entries = append(entries, entry)
+			continue
+		}
+
+		entry.Changes = resourceChanges(&desired, live)
+		if len(entry.Changes) == 0 {
+			entry.Action = planUnchanged
+			entries = append(entries, entry)
+			continue
+		}
+		entry.Action = planUpdate
+		merged := live.DeepCopy()
+		mergeFields(merged.Object, desired.Object)
+		if err := r.Update(ctx, merged, client.DryRunAll); err != nil {
+			entry.Error = err.Error()
+		}
+		entries = append(entries, entry)
+	}
+
+	previous, err := installedManifest(fusionaccess)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range previous {
+		id := resourceID(&res)
+		if planned[id] {
+			continue
+		}
+		live, err := r.getLiveObject(ctx, &res)
+		if err != nil {
+			return nil, err
+		}
+		if live != nil {
+			entries = append(entries, planEntry{Action: planPrune, Resource: id})
+		}
+	}
+	return entries, nil
+}
+
+// installedManifest returns the objects of the shipped manifest of the installed version. It returns
+// nothing when there is no installed version or it is not shipped anymore
+func installedManifest(fusionaccess *fusionv1alpha1.FusionAccess) ([]unstructured.Unstructured, error) {
+	installed := fusionaccess.Status.InstalledStorageScaleVersion
+	if installed == "" {
+		return nil, nil
+	}
+	installPath, err := utils.GetInstallPath(installed)
+	if err != nil {
+		log.Log.Info(fmt.Sprintf("Not planning pruning, the manifest of %s is not available: %v", installed, err))
+		return nil, nil
+	}
+	previous, err := manifestival.NewManifest(installPath)
+	if err != nil {
+		return nil, err
+	}
+	return previous.Resources(), nil
+}
+
+// mergeFields sets the fields of src on dst the same way a JSON merge patch would
+func mergeFields(dst, src map[string]any) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]any)
+		dstMap, dstIsMap := dst[k].(map[string]any)
+		if srcIsMap && dstIsMap {
+			mergeFields(dstMap, srcMap)
+			continue
+		}
+		dst[k] = runtime.DeepCopyJSONValue(v)
+	}
+}
+
+// writeDryRunPlan stores the plan in a ConfigMap next to the FusionAccess object
+func (r *FusionAccessReconciler) writeDryRunPlan(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, entries []planEntry) error {
+	plan, err := yaml.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to render the dry-run plan: %w", err)
+	}
+	counts := map[string]int{}
+	for _, entry := range entries {
+		counts[entry.Action]++
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      dryRunPlanConfigMap,
+			Namespace: fusionaccess.Namespace,
+		},
+		Data: map[string]string{
+			"summary": fmt.Sprintf("storageScaleVersion: %s\nexternalManifestURL: %s\ncreate: %d\nupdate: %d\nprune: %d\nunchanged: %d\n",
+				fusionaccess.Spec.StorageScaleVersion, fusionaccess.Spec.ExternalManifestURL,
+				counts[planCreate], counts[planUpdate], counts[planPrune], counts[planUnchanged]),
+			"plan.yaml": string(plan),
+		},
+	}
+	if err := controllerutil.SetControllerReference(fusionaccess, cm, r.Scheme); err != nil {
+		return err
+	}
+	return kubeutils.CreateOrUpdateResource(ctx, r.Client, cm, func(existing, desired *corev1.ConfigMap) error {
+		existing.Data = desired.Data
+		existing.OwnerReferences = desired.OwnerReferences
+		return nil
+	})
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	mfc "github.com/manifestival/controller-runtime-client"
+	"github.com/manifestival/manifestival"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclient "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("Dry-run plan", func() {
+	var (
+		ctx          = context.Background()
+		cl           client.Client
+		r            *FusionAccessReconciler
+		fusionaccess *fusionv1alpha.FusionAccess
+	)
+
+	newManifest := func(resources ...unstructured.Unstructured) manifestival.Manifest {
+		m, err := manifestival.ManifestFrom(manifestival.Slice(resources), manifestival.UseClient(mfc.NewClient(cl)))
+		Expect(err).ToNot(HaveOccurred())
+		return m
+	}
+
+	BeforeEach(func() {
+		fusionaccess = &fusionv1alpha.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        resourceName,
+				Namespace:   "ibm-fusion-access-operator",
+				Generation:  3,
+				Annotations: map[string]string{dryRunAnnotation: "true"},
+			},
+			Spec: fusionv1alpha.FusionAccessSpec{StorageScaleVersion: "v5.2.3.1.dev3"},
+		}
+		existing := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "ibm-spectrum-scale-manager-config", Namespace: "ibm-spectrum-scale-operator"},
+			Data:       map[string]string{"key": "old"},
+		}
+		r = newFakeReconciler([]client.Object{fusionaccess, existing, newNamespace("ibm-spectrum-scale")},
+			withStatusSubresource(&fusionv1alpha.FusionAccess{}))
+		cl = r.Client
+	})
+
+	It("plans creates, updates and prunes without changing anything", func() {
+		fusionaccess.Status.InstalledStorageScaleVersion = "v5.2.3.1.dev3"
+		added := newManifestConfigMap("new")
+		added.SetName("added")
+
+		entries, err := r.planManifest(ctx, fusionaccess, newManifest(newManifestConfigMap("new"), added))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(ContainElement(planEntry{
+			Action:   planUpdate,
+			Resource: "ConfigMap/ibm-spectrum-scale-operator/ibm-spectrum-scale-manager-config",
+			Changes:  []string{"data.key", "metadata.labels.app.kubernetes.io/name"},
+		}))
+		Expect(entries).To(ContainElement(planEntry{Action: planCreate, Resource: "ConfigMap/ibm-spectrum-scale-operator/added"}))
+		// The namespace exists and belongs to the installed manifest only
+		Expect(entries).To(ContainElement(planEntry{Action: planPrune, Resource: "Namespace/ibm-spectrum-scale"}))
+
+		cm := &corev1.ConfigMap{}
+		Expect(cl.Get(ctx, types.NamespacedName{Namespace: "ibm-spectrum-scale-operator", Name: "ibm-spectrum-scale-manager-config"}, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKeyWithValue("key", "old"))
+		err = cl.Get(ctx, types.NamespacedName{Namespace: "ibm-spectrum-scale-operator", Name: "added"}, &corev1.ConfigMap{})
+		Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("writes the plan to a ConfigMap referenced from the status", func() {
+		Expect(r.reconcileDryRun(ctx, fusionaccess, newManifest(newManifestConfigMap("new")))).To(Succeed())
+
+		updated := &fusionv1alpha.FusionAccess{}
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(fusionaccess), updated)).To(Succeed())
+		Expect(updated.Status.DryRunPlan).To(Equal(&fusionv1alpha.DryRunPlanStatus{
+			ConfigMapName:      dryRunPlanConfigMap,
+			ObservedGeneration: 3,
+			Update:             1,
+		}))
+		Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, fusionv1alpha.ConditionDryRun)).To(BeTrue())
+
+		cm := &corev1.ConfigMap{}
+		Expect(cl.Get(ctx, types.NamespacedName{Namespace: fusionaccess.Namespace, Name: dryRunPlanConfigMap}, cm)).To(Succeed())
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+		Expect(cm.Data["summary"]).To(ContainSubstring("update: 1"))
+		plan := []planEntry{}
+		Expect(yaml.Unmarshal([]byte(cm.Data["plan.yaml"]), &plan)).To(Succeed())
+		Expect(plan).To(HaveLen(1))
+		Expect(plan[0].Action).To(Equal(planUpdate))
+	})
+
+	It("adds no finalizer and stores no manifest cache in dry-run mode", func() {
+		GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", fusionaccess.Namespace)
+		cacheDir := externalManifestCacheDir
+		externalManifestCacheDir = GinkgoT().TempDir()
+		DeferCleanup(func() { externalManifestCacheDir = cacheDir })
+		fusionaccess.Spec.ManifestSource = &fusionv1alpha.ManifestSource{
+			ConfigMaps: []corev1.LocalObjectReference{{Name: "manifest"}},
+		}
+		source := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "manifest", Namespace: fusionaccess.Namespace},
+			Data:       map[string]string{manifestSourceKey: testExternalManifest},
+		}
+		r = newFakeReconciler([]client.Object{fusionaccess, source}, withStatusSubresource(&fusionv1alpha.FusionAccess{}))
+		r.fullClient = kubeclient.NewSimpleClientset()
+		cl = r.Client
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(fusionaccess)})
+		Expect(err).ToNot(HaveOccurred())
+
+		updated := &fusionv1alpha.FusionAccess{}
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(fusionaccess), updated)).To(Succeed())
+		Expect(updated.Finalizers).To(BeEmpty())
+		Expect(updated.Status.DryRunPlan).ToNot(BeNil())
+		err = cl.Get(ctx, types.NamespacedName{Namespace: fusionaccess.Namespace, Name: manifestCacheConfigMap}, &corev1.ConfigMap{})
+		Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("clears the plan once dry-run is disabled", func() {
+		fusionaccess.Status.DryRunPlan = &fusionv1alpha.DryRunPlanStatus{ConfigMapName: dryRunPlanConfigMap}
+		setCondition(fusionaccess, fusionv1alpha.ConditionDryRun, metav1.ConditionTrue, fusionv1alpha.ReasonPlanReady, "")
+		clearDryRun(fusionaccess)
+		Expect(fusionaccess.Status.DryRunPlan).To(BeNil())
+		Expect(meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionDryRun)).To(BeNil())
+	})
+})
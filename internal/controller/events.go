@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+// eventComponent is the name the FusionAccess reconciler records its events with
+const eventComponent = "fusion-access-operator"
+
+// Reasons of the events recorded on the FusionAccess object. They do not change between releases, so
+// that they can be used to filter the events
+const (
+	EventManifestApplied           = "ManifestApplied"
+	EventManifestApplyFailed       = "ManifestApplyFailed"
+	EventPullSecretMissing         = "PullSecretMissing"
+	EventEntitlementSecretsUpdated = "EntitlementSecretsUpdated"
+	EventEntitlementSecretsFailed  = "EntitlementSecretsFailed"
+	EventGlobalPullSecretUpdated   = "GlobalPullSecretUpdated"
+	EventGlobalPullSecretFailed    = "GlobalPullSecretFailed"
+	EventKernelModuleUpdated       = "KernelModuleUpdated"
+	EventKernelModuleFailed        = "KernelModuleFailed"
+	EventImagePullCheckStarted     = "ImagePullCheckStarted"
+	EventImagePullSucceeded        = "ImagePullSucceeded"
+	EventImagePullFailed           = "ImagePullFailed"
+	EventConsolePluginEnabled      = "ConsolePluginEnabled"
+	EventConsolePluginFailed       = "ConsolePluginFailed"
+	EventDeviceDiscoveryCreated    = "DeviceDiscoveryCreated"
+	EventDeviceDiscoveryFailed     = "DeviceDiscoveryFailed"
+	EventStorageClusterHealthy     = "StorageClusterHealthy"
+	EventStorageClusterFailed      = "StorageClusterFailed"
+	EventStorageNodesUpdated       = "StorageNodesUpdated"
+	EventStorageNodesFailed        = "StorageNodesFailed"
+	EventStorageNodesInUse         = "StorageNodesInUse"
+)
+
+// componentEvents are the reasons recorded when a component becomes ready and when it fails, by
+// condition type
+var componentEvents = map[string]struct{ ready, failed string }{
+	fusionv1alpha1.ConditionEntitlementSecrets: {EventEntitlementSecretsUpdated, EventEntitlementSecretsFailed},
+	fusionv1alpha1.ConditionGlobalPullSecret:   {EventGlobalPullSecretUpdated, EventGlobalPullSecretFailed},
+	fusionv1alpha1.ConditionKernelModule:       {EventKernelModuleUpdated, EventKernelModuleFailed},
+	fusionv1alpha1.ConditionImagePull:          {EventImagePullSucceeded, EventImagePullFailed},
+	fusionv1alpha1.ConditionConsolePlugin:      {EventConsolePluginEnabled, EventConsolePluginFailed},
+	fusionv1alpha1.ConditionDeviceDiscovery:    {EventDeviceDiscoveryCreated, EventDeviceDiscoveryFailed},
+	fusionv1alpha1.ConditionStorageCluster:     {EventStorageClusterHealthy, EventStorageClusterFailed},
+	fusionv1alpha1.ConditionStorageNodes:       {EventStorageNodesUpdated, EventStorageNodesFailed},
+}
+
+// componentReady marks a component as reconciled. An event is recorded when it was not ready before,
+// the passes that find it unchanged stay quiet
+func (r *FusionAccessReconciler) componentReady(fusionaccess *fusionv1alpha1.FusionAccess, condType, message string) {
+	if !meta.IsStatusConditionTrue(fusionaccess.Status.Conditions, condType) {
+		r.recorder.Event(fusionaccess, corev1.EventTypeNormal, componentEvents[condType].ready, message)
+	}
+	setCondition(fusionaccess, condType, v1.ConditionTrue, fusionv1alpha1.ReasonReconcileCompleted, message)
+}
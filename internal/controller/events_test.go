@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
+)
+
+var _ = Describe("Events", func() {
+	var (
+		ctx          = context.Background()
+		recorder     *record.FakeRecorder
+		r            *FusionAccessReconciler
+		fusionaccess *fusionv1alpha1.FusionAccess
+	)
+
+	BeforeEach(func() {
+		fusionaccess = &fusionv1alpha1.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "ibm-fusion-access-operator"},
+		}
+		recorder = record.NewFakeRecorder(10)
+		r = newFakeReconciler([]client.Object{fusionaccess},
+			withStatusSubresource(&fusionv1alpha1.FusionAccess{}), withRecorder(recorder))
+	})
+
+	It("records a component once when it becomes ready", func() {
+		r.componentReady(fusionaccess, fusionv1alpha1.ConditionKernelModule, "Kernel module resources were created")
+		r.componentReady(fusionaccess, fusionv1alpha1.ConditionKernelModule, "Kernel module resources were created")
+
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(Equal("Normal KernelModuleUpdated Kernel module resources were created"))
+	})
+
+	It("records the failures of a component with a stable reason", func() {
+		err := errors.New("the ConsolePlugin could not be created")
+		Expect(r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionConsolePlugin, err)).To(MatchError(err))
+
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(Equal("Warning ConsolePluginFailed the ConsolePlugin could not be created"))
+	})
+
+	It("drops the events that repeat within the interval", func() {
+		for range 3 {
+			r.recorder.Event(fusionaccess, corev1.EventTypeWarning, EventPullSecretMissing, "missing")
+		}
+		r.recorder.Event(fusionaccess, corev1.EventTypeWarning, EventPullSecretMissing, "still missing")
+		Expect(recorder.Events).To(HaveLen(2))
+
+		r.recorder = kubeutils.NewEventRecorder(recorder, time.Nanosecond)
+		r.recorder.Event(fusionaccess, corev1.EventTypeWarning, EventPullSecretMissing, "missing")
+		time.Sleep(time.Millisecond)
+		r.recorder.Event(fusionaccess, corev1.EventTypeWarning, EventPullSecretMissing, "missing")
+		Expect(recorder.Events).To(HaveLen(4))
+	})
+
+	It("does nothing without a recorder", func() {
+		r.recorder = nil
+		r.componentReady(fusionaccess, fusionv1alpha1.ConditionKernelModule, "Kernel module resources were created")
+		Expect(recorder.Events).To(BeEmpty())
+	})
+})
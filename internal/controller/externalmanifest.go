@@ -0,0 +1,333 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+var (
+	// externalManifestCacheDir holds the local copies of the external manifests, which are loaded from there
+	externalManifestCacheDir = filepath.Join(os.TempDir(), "fusion-access-manifests")
+	// externalManifestClient downloads the external manifests
+	externalManifestClient = &http.Client{Timeout: 30 * time.Second}
+)
+
+// maxExternalManifestSize is the largest external manifest that is downloaded
+const maxExternalManifestSize = 32 << 20
+
+const (
+	// manifestCacheConfigMap keeps the last verified external manifest, so that a temporary outage of its
+	// source does not break the reconcile, even after the operator was restarted
+	manifestCacheConfigMap = "fusionaccess-manifest-cache"
+	// manifestCacheSourceKey is the ConfigMap key that holds the source of the cached manifest
+	manifestCacheSourceKey = "source"
+	// manifestCacheDataKey is the ConfigMap key that holds the gzipped manifest
+	manifestCacheDataKey = "install.yaml.gz"
+	// manifestCacheLoadedKey is the ConfigMap key that holds the time the cached manifest was last loaded
+	manifestCacheLoadedKey = "loadedAt"
+	// immutableManifest is the maximum age of a cached manifest whose source never changes
+	immutableManifest = time.Duration(math.MaxInt64)
+)
+
+var (
+	// errDigestMismatch is returned when the content of the external manifest does not match the expected digest
+	errDigestMismatch = errors.New("the external manifest does not match the expected digest")
+	// errInvalidManifest is returned when the external manifest lacks the IBM operator configuration, which
+	// the image pull check and the upgrade rely on
+	errInvalidManifest = errors.New("the external manifest does not contain a valid ibm-spectrum-scale-manager-config ConfigMap")
+)
+
+// externalManifest is a local copy of an external manifest
+type externalManifest struct {
+	// Source describes where the manifest was loaded from
+	Source string
+	// Path is the local file to load the manifest from
+	Path string
+	// Digest is the sha256 digest of the manifest
+	Digest string
+	// LoadError is set when loading failed and the cached copy is used instead
+	LoadError error
+}
+
+// usesExternalManifest returns true when the manifest is not the one shipped with the operator
+func usesExternalManifest(spec fusionv1alpha1.FusionAccessSpec) bool {
+	return spec.ManifestSource != nil || spec.ExternalManifestURL != ""
+}
+
+// reconcileExternalManifest loads the external manifest and reports the outcome in the ExternalManifest
+// condition. It returns the local path to load the manifest from
+func (r *FusionAccessReconciler) reconcileExternalManifest(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) (string, error) {
+	manifest, err := r.loadExternalManifest(ctx, fusionaccess)
+	if err != nil {
+		reason := fusionv1alpha1.ReasonManifestFetchFailed
+		switch {
+		case errors.Is(err, errDigestMismatch):
+			reason = fusionv1alpha1.ReasonDigestMismatch
+		case errors.Is(err, errInvalidManifest):
+			reason = fusionv1alpha1.ReasonManifestInvalid
+		}
+		log.Log.Error(err, "Error loading the external manifest")
+		setCondition(fusionaccess, fusionv1alpha1.ConditionExternalManifest, v1.ConditionFalse, reason, err.Error())
+		if serr := r.updateStatus(ctx, fusionaccess); serr != nil {
+			return "", errors.Join(serr, err)
+		}
+		return "", err
+	}
+
+	switch {
+	case manifest.LoadError != nil:
+		log.Log.Info("Using the cached external manifest", "source", manifest.Source, "reason", manifest.LoadError.Error())
+		setCondition(fusionaccess, fusionv1alpha1.ConditionExternalManifest, v1.ConditionTrue, fusionv1alpha1.ReasonCachedManifestUsed,
+			fmt.Sprintf("Using the copy of %s with digest %s cached in ConfigMap %s, loading it failed: %v", manifest.Source, manifest.Digest, manifestCacheConfigMap, manifest.LoadError))
+	case fusionaccess.Spec.ManifestSource != nil:
+		setCondition(fusionaccess, fusionv1alpha1.ConditionExternalManifest, v1.ConditionTrue, fusionv1alpha1.ReasonManifestLoaded,
+			fmt.Sprintf("Loaded the manifest from %s with digest %s", manifest.Source, manifest.Digest))
+	default:
+		setCondition(fusionaccess, fusionv1alpha1.ConditionExternalManifest, v1.ConditionTrue, fusionv1alpha1.ReasonManifestFetched,
+			fmt.Sprintf("Fetched the external manifest with digest %s", manifest.Digest))
+	}
+	return manifest.Path, nil
+}
+
+// loadExternalManifest loads the manifest from spec.manifestSource or spec.externalManifestURL
+func (r *FusionAccessReconciler) loadExternalManifest(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) (*externalManifest, error) {
+	if source := fusionaccess.Spec.ManifestSource; source != nil {
+		return r.loadManifestSource(ctx, fusionaccess, source)
+	}
+	url, err := getIbmManifest(fusionaccess.Spec)
+	if err != nil {
+		return nil, err
+	}
+	return r.fetchExternalManifest(ctx, fusionaccess, url, fusionaccess.Spec.ExternalManifestDigest)
+}
+
+// fetchExternalManifest downloads the manifest, verifies it against the digest if one is given and caches it.
+// If the download fails, the cached copy is returned as long as it matches the digest
+func (r *FusionAccessReconciler) fetchExternalManifest(
+	ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, url, digest string,
+) (*externalManifest, error) {
+	return r.loadCachedManifest(ctx, fusionaccess, url, digest, 0, !isDryRun(fusionaccess), func() ([]byte, error) {
+		return downloadExternalManifest(ctx, url)
+	})
+}
+
+// loadCachedManifest loads and validates a manifest and, when store is set, caches it for the source in the
+// manifest cache ConfigMap. A cached copy of the source that was loaded less than maxAge ago is used without
+// loading it again. If loading fails, the cached copy is returned as long as it was loaded from the same
+// source and matches the digest
+func (r *FusionAccessReconciler) loadCachedManifest(
+	ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, source, digest string, maxAge time.Duration, store bool,
+	load func() ([]byte, error),
+) (*externalManifest, error) {
+	path := filepath.Join(externalManifestCacheDir, fmt.Sprintf("%x.yaml", sha256.Sum256([]byte(source))))
+
+	if maxAge > 0 {
+		cached, loadedAt, err := r.readCachedManifest(ctx, fusionaccess.Namespace, source)
+		if err == nil && cached != nil && time.Since(loadedAt) < maxAge {
+			actual := manifestDigest(cached)
+			if digest == "" || actual == digest {
+				if err := writeCachedManifest(path, cached); err != nil {
+					return nil, err
+				}
+				return &externalManifest{Source: source, Path: path, Digest: actual}, nil
+			}
+		}
+	}
+
+	data, loadErr := load()
+	if loadErr == nil {
+		actual := manifestDigest(data)
+		if digest != "" && actual != digest {
+			return nil, fmt.Errorf("%w: expected %s, got %s", errDigestMismatch, digest, actual)
+		}
+		if _, err := utils.ParseYAMLAndExtractTestImage(string(data)); err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidManifest, err)
+		}
+		if err := writeCachedManifest(path, data); err != nil {
+			return nil, err
+		}
+		// The ConfigMap only matters when the source fails later on, so failing to write it is not fatal
+		if !store {
+			log.Log.Info("Not storing the manifest cache ConfigMap in dry-run mode", "source", source)
+		} else if err := r.storeCachedManifest(ctx, fusionaccess, source, data, maxAge > 0); err != nil {
+			log.Log.Error(err, "Error storing the manifest cache ConfigMap", "source", source)
+		}
+		return &externalManifest{Source: source, Path: path, Digest: actual}, nil
+	}
+
+	cached, _, err := r.readCachedManifest(ctx, fusionaccess.Namespace, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s and the cached copy cannot be read: %v: %w", source, err, loadErr)
+	}
+	if cached == nil {
+		return nil, fmt.Errorf("failed to load %s and no cached copy exists: %w", source, loadErr)
+	}
+	actual := manifestDigest(cached)
+	if digest != "" && actual != digest {
+		return nil, fmt.Errorf("failed to load %s and the cached copy has digest %s instead of %s: %w", source, actual, digest, loadErr)
+	}
+	if err := writeCachedManifest(path, cached); err != nil {
+		return nil, err
+	}
+	return &externalManifest{Source: source, Path: path, Digest: actual, LoadError: loadErr}, nil
+}
+
+// storeCachedManifest keeps the manifest of the source gzipped in the manifest cache ConfigMap, which is
+// owned by the FusionAccess. The ConfigMap is only written when its content changes, or when reload is
+// set and the time the manifest was loaded must be recorded
+func (r *FusionAccessReconciler) storeCachedManifest(
+	ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, source string, data []byte, reload bool,
+) error {
+	existing := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: fusionaccess.Namespace, Name: manifestCacheConfigMap}, existing)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err == nil && !reload && existing.Data[manifestCacheSourceKey] == source {
+		if cached, err := gunzip(existing.BinaryData[manifestCacheDataKey]); err == nil && bytes.Equal(cached, data) {
+			return nil
+		}
+	}
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      manifestCacheConfigMap,
+			Namespace: fusionaccess.Namespace,
+		},
+		Data: map[string]string{
+			manifestCacheSourceKey: source,
+			manifestCacheLoadedKey: time.Now().UTC().Format(time.RFC3339),
+		},
+		BinaryData: map[string][]byte{manifestCacheDataKey: compressed.Bytes()},
+	}
+	if err := controllerutil.SetControllerReference(fusionaccess, cm, r.Scheme); err != nil {
+		return err
+	}
+	return kubeutils.CreateOrUpdateResource(ctx, r.Client, cm, func(existing, desired *corev1.ConfigMap) error {
+		existing.Data = desired.Data
+		existing.BinaryData = desired.BinaryData
+		existing.OwnerReferences = desired.OwnerReferences
+		return nil
+	})
+}
+
+// readCachedManifest returns the manifest in the manifest cache ConfigMap and the time it was loaded, or
+// nil when it does not hold a manifest of the source. The time is zero when it is unknown
+func (r *FusionAccessReconciler) readCachedManifest(ctx context.Context, ns, source string) ([]byte, time.Time, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: ns, Name: manifestCacheConfigMap}, cm); err != nil {
+		return nil, time.Time{}, client.IgnoreNotFound(err)
+	}
+	if cm.Data[manifestCacheSourceKey] != source {
+		return nil, time.Time{}, nil
+	}
+	data, err := gunzip(cm.BinaryData[manifestCacheDataKey])
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	loadedAt, _ := time.Parse(time.RFC3339, cm.Data[manifestCacheLoadedKey])
+	return data, loadedAt, nil
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(io.LimitReader(zr, maxExternalManifestSize+1))
+}
+
+func downloadExternalManifest(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := externalManifestClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxExternalManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxExternalManifestSize {
+		return nil, fmt.Errorf("the manifest is larger than %d bytes", maxExternalManifestSize)
+	}
+	return data, nil
+}
+
+// writeCachedManifest replaces the cached copy atomically, so that a failed write never leaves a partial manifest
+func writeCachedManifest(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create the manifest cache: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".manifest-*")
+	if err != nil {
+		return fmt.Errorf("failed to cache the manifest: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to cache the manifest: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to cache the manifest: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func manifestDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+// testExternalManifest is the smallest manifest with the IBM operator configuration
+const testExternalManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ibm-spectrum-scale-manager-config
+  namespace: ibm-spectrum-scale-operator
+data:
+  controller_manager_config.yaml: |
+    images:
+      coreInit: quay.io/example/core-init@sha256:abc123
+`
+
+var _ = Describe("External manifest", func() {
+	const ns = "ibm-fusion-access-operator"
+	var (
+		ctx          = context.Background()
+		r            *FusionAccessReconciler
+		fusionaccess *fusionv1alpha.FusionAccess
+		server       *httptest.Server
+		available    bool
+		manifest     string
+	)
+
+	BeforeEach(func() {
+		cacheDir := externalManifestCacheDir
+		externalManifestCacheDir = GinkgoT().TempDir()
+		DeferCleanup(func() { externalManifestCacheDir = cacheDir })
+
+		available = true
+		manifest = testExternalManifest
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if !available {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(manifest))
+		}))
+		DeferCleanup(server.Close)
+		GinkgoT().Setenv(utils.ExternalManifestAllowedPrefixesEnvVar, server.URL)
+
+		fusionaccess = &fusionv1alpha.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: ns},
+			Spec: fusionv1alpha.FusionAccessSpec{
+				ExternalManifestURL:    server.URL + "/install.yaml",
+				ExternalManifestDigest: manifestDigest([]byte(manifest)),
+			},
+		}
+		r = newFakeReconciler([]client.Object{fusionaccess}, withStatusSubresource(&fusionv1alpha.FusionAccess{}))
+	})
+
+	It("fetches, verifies and caches the manifest", func() {
+		path, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		data, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(manifest))
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond).ToNot(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonManifestFetched))
+	})
+
+	It("rejects a manifest that does not match the digest", func() {
+		fusionaccess.Spec.ExternalManifestDigest = manifestDigest([]byte("something else"))
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).To(MatchError(errDigestMismatch))
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonDigestMismatch))
+	})
+
+	It("uses the cached copy when the server is not available", func() {
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+
+		available = false
+		path, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		data, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(manifest))
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonCachedManifestUsed))
+		Expect(cond.Message).To(ContainSubstring("503"))
+	})
+
+	It("keeps the cached copy in a ConfigMap that survives a restart", func() {
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+
+		cm := &corev1.ConfigMap{}
+		Expect(r.Get(ctx, types.NamespacedName{Namespace: ns, Name: manifestCacheConfigMap}, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKeyWithValue(manifestCacheSourceKey, fusionaccess.Spec.ExternalManifestURL))
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+		Expect(cm.OwnerReferences[0].Name).To(Equal(fusionaccess.Name))
+
+		// A restart loses the local copies
+		externalManifestCacheDir = GinkgoT().TempDir()
+		available = false
+		path, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		data, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(manifest))
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonCachedManifestUsed))
+	})
+
+	It("does not use the cached copy of another source", func() {
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+
+		available = false
+		fusionaccess.Spec.ExternalManifestURL = server.URL + "/other.yaml"
+		_, err = r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("no cached copy exists"))
+	})
+
+	It("does not use a cached copy that does not match the digest", func() {
+		fusionaccess.Spec.ExternalManifestDigest = ""
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+
+		available = false
+		_, err = r.fetchExternalManifest(ctx, fusionaccess, fusionaccess.Spec.ExternalManifestURL, manifestDigest([]byte("something else")))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("the cached copy has digest"))
+	})
+
+	It("fails when the server is not available and nothing is cached", func() {
+		available = false
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).To(HaveOccurred())
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonManifestFetchFailed))
+		Expect(cond.Message).To(ContainSubstring("no cached copy exists"))
+	})
+
+	It("rejects a manifest without the IBM operator configuration", func() {
+		manifest = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: ibm-spectrum-scale\n"
+		fusionaccess.Spec.ExternalManifestDigest = ""
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).To(MatchError(errInvalidManifest))
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonManifestInvalid))
+	})
+
+	It("reports a URL that is not allowed", func() {
+		GinkgoT().Setenv(utils.ExternalManifestAllowedPrefixesEnvVar, "https://mirror.example.com/")
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).To(HaveOccurred())
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("disallowed URL"))
+	})
+})
@@ -21,13 +21,18 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 
 	mfc "github.com/manifestival/controller-runtime-client"
 	"github.com/manifestival/manifestival"
+	configv1 "github.com/openshift/api/config/v1"
+	consolev1 "github.com/openshift/api/console/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	kmmv1beta1 "github.com/rh-ecosystem-edge/kernel-module-management/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 
-	meta "k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
@@ -36,6 +41,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -43,14 +49,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/console"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/kernelmodule"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/localvolumediscovery"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
 )
 
-type CanPullImageFunc func(ctx context.Context, client kubernetes.Interface, ns, image, pullSecret string) (bool, error)
-
 // FusionAccessReconciler reconciles a FusionAccess object
 type FusionAccessReconciler struct {
 	client.Client
@@ -58,8 +64,7 @@ type FusionAccessReconciler struct {
 	config        *rest.Config
 	dynamicClient dynamic.Interface
 	fullClient    kubernetes.Interface
-	// Need this for mocking when needed
-	CanPullImage CanPullImageFunc
+	recorder      *kubeutils.EventRecorder
 }
 
 func NewFusionAccessReconciler(
@@ -67,14 +72,11 @@ func NewFusionAccessReconciler(
 	scheme *runtime.Scheme,
 ) *FusionAccessReconciler {
 	return &FusionAccessReconciler{
-		Client:       myClient,
-		Scheme:       scheme,
-		CanPullImage: utils.CanPullImage,
+		Client: myClient,
+		Scheme: scheme,
 	}
 }
 
-// const storageScaleFinalizer = "fusion.storage.openshift.io/finalizer"
-
 // Basic Operator RBACs
 //+kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=fusionaccesses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=fusionaccesses/status,verbs=get;update;patch
@@ -85,6 +87,9 @@ func NewFusionAccessReconciler(
 // KMM support
 //+kubebuilder:rbac:groups=kmm.sigs.x-k8s.io,resources=modules,verbs=create;delete;get;list;patch;update;watch
 
+// OLM Upgradeable condition
+//+kubebuilder:rbac:groups=operators.coreos.com,resources=operatorconditions,verbs=get;list;watch;update;patch
+
 // Below rules are inserted via `make rbac-generate` automatically
 // IBM_RBAC_MARKER_START
 //+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=list;watch;delete;update;get;create;patch
@@ -103,8 +108,11 @@ func NewFusionAccessReconciler(
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list
 //+kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=config.openshift.io,resources=dnses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=config.openshift.io,resources=imagedigestmirrorsets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=config.openshift.io,resources=imagetagmirrorsets,verbs=get;list;watch
 //+kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures,verbs=get;list;watch
 //+kubebuilder:rbac:groups=config.openshift.io,resources=networks,verbs=get;list;watch
+//+kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get;list;watch
 //+kubebuilder:rbac:groups=coordination.k8s.io,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=create;delete;get;list;update;watch
 //+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
@@ -118,6 +126,7 @@ func NewFusionAccessReconciler(
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies/finalizers,verbs=update
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies/status,verbs=get;patch;update
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups=operator.openshift.io,resources=imagecontentsourcepolicies,verbs=get;list;watch
 //+kubebuilder:rbac:groups=oauth.openshift.io,resources=oauthclients,verbs=create;get;list;patch;watch
 //+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;delete;get;list;patch;watch
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=*
@@ -282,43 +291,51 @@ func (r *FusionAccessReconciler) Reconcile(
 		}
 		return ctrl.Result{}, err
 	}
+	// The entitlement key and the registry it is used for come from the operator configuration,
+	// the teardown needs them too
+	if err := common.LoadOperatorConfig(ctx, r.Client); err != nil {
+		return ctrl.Result{}, err
+	}
 
-	// // Check if the FusionAccess instance is marked to be deleted, which is
-	// // indicated by the deletion timestamp being set.
-	// isFusionAccessMarkedToBeDeleted := fusionaccess.GetDeletionTimestamp() != nil
-	// if isFusionAccessMarkedToBeDeleted {
-	// 	if controllerutil.ContainsFinalizer(fusionaccess, storageScaleFinalizer) {
-	// 		// Run finalization logic for storageScaleFinalizer. If the
-	// 		// finalization logic fails, don't remove the finalizer so
-	// 		// that we can retry during the next reconciliation.
-	// 		if err := r.finalizeFusionAccess(log.Log, fusionaccess); err != nil {
-	// 			return ctrl.Result{}, err
-	// 		}
-
-	// 		// Remove memcachedFinalizer. Once all finalizers have been
-	// 		// removed, the object will be deleted.
-	// 		controllerutil.RemoveFinalizer(fusionaccess, storageScaleFinalizer)
-	// 		err := r.Update(ctx, fusionaccess)
-	// 		if err != nil {
-	// 			return ctrl.Result{}, err
-	// 		}
-	// 	}
-	// 	return ctrl.Result{}, nil
-	// }
-	// // Add finalizer for this CR
-	// if !controllerutil.ContainsFinalizer(fusionaccess, storageScaleFinalizer) {
-	// 	controllerutil.AddFinalizer(fusionaccess, storageScaleFinalizer)
-	// 	err = r.Update(ctx, fusionaccess)
-	// 	if err != nil {
-	// 		return ctrl.Result{}, err
-	// 	}
-	// }
+	// Check if the FusionAccess instance is marked to be deleted, which is
+	// indicated by the deletion timestamp being set.
+	if fusionaccess.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(fusionaccess, storageScaleFinalizer) {
+			// The teardown changes the cluster too, it waits until reconciliation is resumed
+			if fusionaccess.IsPaused() {
+				log.Log.Info("Reconciliation is paused, the deletion waits until it is resumed")
+				return ctrl.Result{}, nil
+			}
+			// Run finalization logic for storageScaleFinalizer. If the
+			// finalization logic fails, don't remove the finalizer so
+			// that we can retry during the next reconciliation.
+			return r.finalizeFusionAccess(ctx, fusionaccess)
+		}
+		return ctrl.Result{}, nil
+	}
 	ns, err := utils.GetDeploymentNamespace()
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	install_path, err := getIbmManifest(fusionaccess.Spec)
+	if fusionaccess.IsPaused() {
+		return r.reconcilePaused(ctx, ns, fusionaccess)
+	}
+	resumeReconcile(fusionaccess)
+
+	var install_path string
+	if usesExternalManifest(fusionaccess.Spec) {
+		install_path, err = r.reconcileExternalManifest(ctx, fusionaccess)
+	} else {
+		setCondition(fusionaccess, fusionv1alpha1.ConditionExternalManifest, v1.ConditionTrue,
+			fusionv1alpha1.ReasonNotApplicable, "The manifest shipped with the operator is used")
+		if err = utils.ValidateStorageScaleVersion(string(fusionaccess.Spec.StorageScaleVersion)); err == nil {
+			install_path, err = getIbmManifest(fusionaccess.Spec)
+		}
+		if errors.Is(err, utils.ErrUnknownStorageScaleVersion) {
+			return ctrl.Result{}, r.invalidVersion(ctx, fusionaccess, err)
+		}
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -330,23 +347,82 @@ func (r *FusionAccessReconciler) Reconcile(
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	installManifest, err = r.reconcileManifestPatches(ctx, fusionaccess, installManifest)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	installManifest, err = r.injectClusterProxy(ctx, installManifest)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 	log.Log.Info(fmt.Sprintf("Applying manifest from %s", install_path))
 
-	if err := installManifest.Apply(); err != nil {
+	// In dry-run mode only the plan is written, nothing else is changed on the cluster
+	if isDryRun(fusionaccess) {
+		return ctrl.Result{}, r.reconcileDryRun(ctx, fusionaccess, installManifest)
+	}
+	clearDryRun(fusionaccess)
+
+	// Add finalizer for this CR, the dry-run above must not change the object
+	if !controllerutil.ContainsFinalizer(fusionaccess, storageScaleFinalizer) {
+		controllerutil.AddFinalizer(fusionaccess, storageScaleFinalizer)
+		// The update returns the stored status, the conditions set above are kept
+		status := fusionaccess.Status.DeepCopy()
+		err = r.Update(ctx, fusionaccess)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		fusionaccess.Status = *status
+	}
+
+	// Whatever was reported by a previous pass is recomputed from the conditions below
+	fusionaccess.Status.Status = "NotReady"
+
+	appliedHash := ""
+	if fusionaccess.Status.Manifest != nil {
+		appliedHash = fusionaccess.Status.Manifest.Hash
+	}
+	manifestResult := ctrl.Result{}
+	if isUpgrade(fusionaccess) {
+		done, result, err := r.reconcileUpgrade(ctx, fusionaccess, installManifest)
+		if err != nil || !done {
+			return result, err
+		}
+		// The upgrade applied the whole manifest
+		hash, err := manifestHash(installManifest)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		setManifestApplied(fusionaccess, hash)
+	} else if manifestResult, err = r.reconcileManifest(ctx, fusionaccess, installManifest); err != nil {
 		log.Log.Error(err, "Error applying manifest")
+		r.recorder.Eventf(fusionaccess, corev1.EventTypeWarning, EventManifestApplyFailed,
+			"Failed to apply the Storage Scale manifest: %v", err)
 		fusionaccess.Status.Status = "Error"
-		meta.SetStatusCondition(&fusionaccess.Status.Conditions,
-			v1.Condition{Type: "ManifestApply", Status: v1.ConditionFalse, Reason: "ReconcileCompleted", Message: "Storage Scale manifest apply failed"})
-		serr := r.Status().Update(ctx, fusionaccess)
+		setCondition(fusionaccess, fusionv1alpha1.ConditionManifestApply, v1.ConditionFalse,
+			fusionv1alpha1.ReasonReconcileFailed, fmt.Sprintf("Storage Scale manifest apply failed: %v", err))
+		serr := r.updateStatus(ctx, fusionaccess)
 		if serr != nil {
 			return ctrl.Result{}, errors.Join(serr, err)
 		}
 		return ctrl.Result{}, err
 	}
 	log.Log.Info(fmt.Sprintf("Applied manifest from %s", install_path))
-	meta.SetStatusCondition(&fusionaccess.Status.Conditions,
-		v1.Condition{Type: "ManifestApply", Status: v1.ConditionTrue, Reason: "ReconcileCompleted", Message: "Storage Scale manifest was applied"})
-	serr := r.Status().Update(ctx, fusionaccess)
+	if fusionaccess.Status.Manifest != nil && fusionaccess.Status.Manifest.Hash != appliedHash {
+		r.recorder.Eventf(fusionaccess, corev1.EventTypeNormal, EventManifestApplied,
+			"Applied the Storage Scale %s manifest", fusionaccess.Spec.StorageScaleVersion)
+	}
+	if fusionaccess.Status.InstalledStorageScaleVersion == "" {
+		fusionaccess.Status.InstalledStorageScaleVersion = string(fusionaccess.Spec.StorageScaleVersion)
+	}
+	setCondition(fusionaccess, fusionv1alpha1.ConditionManifestApply, v1.ConditionTrue,
+		fusionv1alpha1.ReasonReconcileCompleted, "Storage Scale manifest was applied")
+
+	operatorAvailable, err := r.setStorageScaleOperatorCondition(ctx, fusionaccess)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	serr := r.updateStatus(ctx, fusionaccess)
 	if serr != nil {
 		return ctrl.Result{}, serr
 	}
@@ -359,73 +435,174 @@ func (r *FusionAccessReconciler) Reconcile(
 		log.Log.Info(
 			"Pull secret not found, skipping entitlement secret creation, we will watch this secret",
 		)
+		message := fmt.Sprintf("The %s secret was not found in namespace %s", FUSIONPULLSECRETNAME, ns)
+		for _, condType := range []string{
+			fusionv1alpha1.ConditionEntitlementSecrets,
+			fusionv1alpha1.ConditionGlobalPullSecret,
+			fusionv1alpha1.ConditionKernelModule,
+		} {
+			setCondition(fusionaccess, condType, v1.ConditionFalse, fusionv1alpha1.ReasonPullSecretMissing, message)
+		}
+		r.recorder.Event(fusionaccess, corev1.EventTypeWarning, EventPullSecretMissing, message)
 	} else {
 		// Create entitlement secrets
 		err = updateEntitlementPullSecrets(secret, ctx, r.fullClient, ns)
 		if err != nil {
 			log.Log.Error(err, "Error creating entitlement secrets")
-			return reconcile.Result{}, err
+			return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionEntitlementSecrets, err)
 		}
+		r.componentReady(fusionaccess, fusionv1alpha1.ConditionEntitlementSecrets, "IBM entitlement secrets were created")
 		log.Log.Info("Entitlement secrets created")
 
 		// Since the kernel module requires the pull secret, we only create that if the secret is found
-		log.Log.Info("Creating kernel module resources")
-		if err := kernelmodule.CreateOrUpdateKMMResources(ctx, r.Client); err != nil {
-			return ctrl.Result{}, err
+		if err := kernelmodule.UpdateGlobalPullSecret(ctx, r.Client); err != nil {
+			return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionGlobalPullSecret, err)
 		}
+		r.componentReady(fusionaccess, fusionv1alpha1.ConditionGlobalPullSecret,
+			"IBM registry credentials were added to the global pull secret")
 
+		log.Log.Info("Creating kernel module resources")
+		if err := kernelmodule.CreateOrUpdateKernelModule(ctx, r.Client, fusionaccess); err != nil {
+			return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionKernelModule, err)
+		}
+		r.componentReady(fusionaccess, fusionv1alpha1.ConditionKernelModule, "Kernel module resources were created")
 		log.Log.Info("Successfully created kernel module resources")
 	}
+	serr = r.updateStatus(ctx, fusionaccess)
+	if serr != nil {
+		return ctrl.Result{}, serr
+	}
 
 	// Check if can pull the image if we have not already or if it failed previously
 	// Only do this check if we have a set cnsa version
+	imagePullResult := ctrl.Result{}
 	if fusionaccess.Spec.StorageScaleVersion != "" {
-		err = r.runPullImageCheck(ctx, ns, fusionaccess)
+		imagePullResult, err = r.reconcileImagePullCheck(ctx, ns, fusionaccess)
 		if err != nil {
 			fusionaccess.Status.Status = "ErrImagePull"
-			meta.SetStatusCondition(&fusionaccess.Status.Conditions,
-				v1.Condition{Type: "ImagePull", Status: v1.ConditionFalse, Reason: "ImagePullDone", Message: "protected images can't be pulled"})
-			serr := r.Status().Update(ctx, fusionaccess)
-			if serr != nil {
-				return ctrl.Result{}, errors.Join(serr, err)
-			}
-			return ctrl.Result{}, err
-		}
-		meta.SetStatusCondition(&fusionaccess.Status.Conditions,
-			v1.Condition{Type: "ImagePull", Status: v1.ConditionTrue, Reason: "ImagePullDone", Message: "protected images pulled successfully"})
-		serr := r.Status().Update(ctx, fusionaccess)
-		if serr != nil {
-			return ctrl.Result{}, serr
+			return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionImagePull, err)
 		}
 	} else {
 		log.Log.Info("Skipping image pull check as we are not using a Storage Scale version in the spec")
+		setCondition(fusionaccess, fusionv1alpha1.ConditionImagePull, v1.ConditionTrue,
+			fusionv1alpha1.ReasonNotApplicable, "No Storage Scale version is set, the image pull check is skipped")
+	}
+	serr = r.updateStatus(ctx, fusionaccess)
+	if serr != nil {
+		return ctrl.Result{}, serr
 	}
 
-	if err := console.CreateOrUpdatePlugin(ctx, r.Client); err != nil {
-		return ctrl.Result{}, err
+	if err := console.CreateOrUpdatePlugin(ctx, r.Client, fusionaccess); err != nil {
+		return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionConsolePlugin, err)
 	}
 	log.Log.Info("Successfully created / updated console plugin resources")
 
 	if err := console.EnablePlugin(ctx, r.Client); err != nil {
-		return ctrl.Result{}, err
+		return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionConsolePlugin, err)
 	}
+	r.componentReady(fusionaccess, fusionv1alpha1.ConditionConsolePlugin, "Console plugin is enabled")
 	log.Log.Info("Successfully enabled console plugin")
 
 	if fusionaccess.Spec.LocalVolumeDiscovery.Create {
 		// Create Device discovery
 
 		lvd := localvolumediscovery.NewLocalVolumeDiscovery(ns)
+		if err := kubeutils.SetOwner(fusionaccess, lvd, r.Scheme); err != nil {
+			return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionDeviceDiscovery, err)
+		}
 		if err := localvolumediscovery.CreateOrUpdateLocalVolumeDiscovery(ctx, lvd, r.Client); err != nil {
-			return ctrl.Result{}, err
+			return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionDeviceDiscovery, err)
 		}
+		r.componentReady(fusionaccess, fusionv1alpha1.ConditionDeviceDiscovery, "Device discovery was created")
+	} else {
+		setCondition(fusionaccess, fusionv1alpha1.ConditionDeviceDiscovery, v1.ConditionTrue,
+			fusionv1alpha1.ReasonDisabled, "Device discovery is disabled in the spec")
+	}
+
+	// The storage nodes are labelled before the storage cluster selects them
+	if err := r.reconcileStorageNodes(ctx, fusionaccess); err != nil {
+		return ctrl.Result{}, err
+	}
+	storageClusterResult, err := r.reconcileStorageCluster(ctx, fusionaccess, operatorAvailable)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The capacity is informational only, a failure to compute it must not hold up the rest
+	if err := r.reconcileCapacity(ctx, ns, fusionaccess); err != nil {
+		log.Log.Error(err, "Error computing the device capacity")
+	}
+	if err := r.reconcileRequiredImages(ctx, ns, fusionaccess, installManifest); err != nil {
+		log.Log.Error(err, "Error publishing the required images")
+	}
+	if err := r.reconcileSupported(ctx, fusionaccess); err != nil {
+		log.Log.Error(err, "Error checking if the Storage Scale version is supported")
+	}
+	if err := r.reconcileUpgradeable(ctx, fusionaccess); err != nil {
+		log.Log.Error(err, "Error checking if OpenShift can be upgraded")
 	}
 
-	fusionaccess.Status.Status = "Ready"
-	err = r.Status().Update(ctx, fusionaccess)
+	err = r.updateStatus(ctx, fusionaccess)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+	results := []ctrl.Result{manifestResult, imagePullResult, storageClusterResult, refreshCapacity}
+	if !operatorAvailable {
+		results = append(results, waitForComponents)
+	}
+	return soonestRequeue(results...), nil
+}
+
+// soonestRequeue returns the result that requeues first, ignoring the ones that do not requeue
+func soonestRequeue(results ...ctrl.Result) ctrl.Result {
+	soonest := ctrl.Result{}
+	for _, result := range results {
+		if result.RequeueAfter > 0 && (soonest.RequeueAfter == 0 || result.RequeueAfter < soonest.RequeueAfter) {
+			soonest = result
+		}
+	}
+	return soonest
+}
+
+// invalidVersion reports a storageScaleVersion that is not in the release catalog, which the webhook would
+// have rejected. Retrying does not help, the FusionAccess is reconciled again once the version is changed
+func (r *FusionAccessReconciler) invalidVersion(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, err error) error {
+	log.Log.Error(err, "Invalid IBM Storage Scale version", "version", fusionaccess.Spec.StorageScaleVersion)
+	r.recorder.Event(fusionaccess, corev1.EventTypeWarning, EventManifestApplyFailed, err.Error())
+	setCondition(fusionaccess, fusionv1alpha1.ConditionManifestApply, v1.ConditionFalse, fusionv1alpha1.ReasonVersionInvalid, err.Error())
+	return r.updateStatus(ctx, fusionaccess)
+}
+
+// componentFailed marks a component as failed and returns the original error joined with any status update error
+func (r *FusionAccessReconciler) componentFailed(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, condType string, err error) error {
+	r.recorder.Event(fusionaccess, corev1.EventTypeWarning, componentEvents[condType].failed, err.Error())
+	setCondition(fusionaccess, condType, v1.ConditionFalse, fusionv1alpha1.ReasonReconcileFailed, err.Error())
+	if serr := r.updateStatus(ctx, fusionaccess); serr != nil {
+		return errors.Join(serr, err)
+	}
+	return err
+}
+
+// setStorageScaleOperatorCondition reports whether the IBM operator deployments from the manifest are available
+func (r *FusionAccessReconciler) setStorageScaleOperatorCondition(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) (bool, error) {
+	pending := []string{}
+	for _, deployment := range IBMOperatorDeployments {
+		rolledOut, err := r.isDeploymentRolledOut(ctx, deployment)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return false, err
+		}
+		if !rolledOut {
+			pending = append(pending, deployment.String())
+		}
+	}
+	if len(pending) > 0 {
+		setCondition(fusionaccess, fusionv1alpha1.ConditionStorageScaleOperator, v1.ConditionFalse,
+			fusionv1alpha1.ReasonDeploymentsProgressing, fmt.Sprintf("Waiting for deployments: %s", strings.Join(pending, ", ")))
+		return false, nil
+	}
+	setCondition(fusionaccess, fusionv1alpha1.ConditionStorageScaleOperator, v1.ConditionTrue,
+		fusionv1alpha1.ReasonDeploymentsAvailable, "IBM Storage Scale operator deployments are available")
+	return true, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -438,6 +615,7 @@ func (r *FusionAccessReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if r.fullClient, err = kubernetes.NewForConfig(r.config); err != nil {
 		return err
 	}
+	r.recorder = kubeutils.NewEventRecorder(mgr.GetEventRecorderFor(eventComponent), kubeutils.DefaultEventInterval)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&fusionv1alpha1.FusionAccess{}).
 		Watches(
@@ -445,6 +623,68 @@ func (r *FusionAccessReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.getPullSecretSelector),
 			isItOurPullSecret(),
 		).
+		// The ConfigMaps the manifest is loaded or patched from, and the ones created by the reconciler
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.getConfigMapRequests),
+			r.inFusionAccessNamespace(),
+		).
+		Watches(
+			&fusionv1alpha1.LocalVolumeDiscoveryResult{},
+			handler.EnqueueRequestsFromMapFunc(r.getFusionAccessRequests),
+		).
+		Watches(
+			&configv1.ClusterVersion{},
+			handler.EnqueueRequestsFromMapFunc(r.getFusionAccessRequests),
+			clusterVersionChanged(),
+		).
+		Watches(
+			&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(r.getFusionAccessRequests),
+			nodeLabelsChanged(),
+		).
+		Watches(
+			&fusionv1alpha1.StorageScaleRelease{},
+			handler.EnqueueRequestsFromMapFunc(r.getFusionAccessRequests),
+		).
+		Watches(
+			&configv1.Proxy{},
+			handler.EnqueueRequestsFromMapFunc(r.getFusionAccessRequests),
+		).
+		Watches(
+			&configv1.ImageDigestMirrorSet{},
+			handler.EnqueueRequestsFromMapFunc(r.getFusionAccessRequests),
+		).
+		Watches(
+			&configv1.ImageTagMirrorSet{},
+			handler.EnqueueRequestsFromMapFunc(r.getFusionAccessRequests),
+		).
+		Watches(
+			&operatorv1alpha1.ImageContentSourcePolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.getFusionAccessRequests),
+		).
+		Watches(
+			&fusionv1alpha1.FusionAccessOperatorConfig{},
+			handler.EnqueueRequestsFromMapFunc(r.getFusionAccessRequests),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		// The objects created by the reconciler are repaired as soon as they are changed or deleted.
+		// Their status changes all the time and is ignored
+		Watches(
+			&fusionv1alpha1.LocalVolumeDiscovery{},
+			handler.EnqueueRequestsFromMapFunc(kubeutils.OwnerRequests),
+			ownedObjectChanged(),
+		).
+		Watches(
+			&kmmv1beta1.Module{},
+			handler.EnqueueRequestsFromMapFunc(kubeutils.OwnerRequests),
+			ownedObjectChanged(),
+		).
+		Watches(
+			&consolev1.ConsolePlugin{},
+			handler.EnqueueRequestsFromMapFunc(kubeutils.OwnerRequests),
+			ownedObjectChanged(),
+		).
 		Complete(r)
 }
 
@@ -460,12 +700,24 @@ func (r *FusionAccessReconciler) getPullSecretSelector(
 		// The secret in the namespace is not there yet
 		return []reconcile.Request{}
 	}
+	return r.getFusionAccessRequests(ctx, nil)
+}
+
+// getFusionAccessRequests enqueues the FusionAccess instance when one of the objects it depends on changes
+func (r *FusionAccessReconciler) getFusionAccessRequests(
+	ctx context.Context,
+	_ client.Object,
+) []reconcile.Request {
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return []reconcile.Request{}
+	}
 	fusionAccessList := &fusionv1alpha1.FusionAccessList{}
 	if err := r.List(ctx, fusionAccessList, client.InNamespace(ns)); err != nil {
 		return nil
 	}
 	if len(fusionAccessList.Items) == 0 {
-		log.Log.Info("No FusionAccess instance found, skipping pull secret")
+		log.Log.Info("No FusionAccess instance found, skipping")
 		return []reconcile.Request{}
 	}
 
@@ -477,19 +729,15 @@ func (r *FusionAccessReconciler) getPullSecretSelector(
 	return []reconcile.Request{req}
 }
 
-func (r *FusionAccessReconciler) runPullImageCheck(ctx context.Context, ns string, fusionaccess *fusionv1alpha1.FusionAccess) error {
-	testImage, err := utils.GetExternalTestImage(string(fusionaccess.Spec.StorageScaleVersion))
-	if err != nil {
-		log.Log.Error(err, "Could not figure out test image", "testImage", testImage)
-		return err
-	}
-	ok, err := r.CanPullImage(ctx, r.fullClient, ns, testImage, IBMENTITLEMENTNAME)
-	if ok {
-		log.Log.Info("Image pull test succeeded", "ns", ns, "testImage", testImage)
-	} else {
-		log.Log.Error(err, "Image pull test failed", "ns", ns, "testImage", testImage)
+// getConfigMapRequests enqueues the FusionAccess that loads its manifest from a ConfigMap or created it
+func (r *FusionAccessReconciler) getConfigMapRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := r.getManifestConfigMapRequests(ctx, obj)
+	for _, request := range kubeutils.OwnerRequests(ctx, obj) {
+		if !slices.Contains(requests, request) {
+			requests = append(requests, request)
+		}
 	}
-	return nil
+	return requests
 }
 
 func getIbmManifest(fusionobj fusionv1alpha1.FusionAccessSpec) (string, error) {
@@ -553,6 +801,55 @@ func isItOurPullSecret() builder.WatchesOption {
 	})
 }
 
+// inFusionAccessNamespace only lets through the objects in a namespace that holds a FusionAccess, the
+// ConfigMaps it references and the ones created for it live there
+func (r *FusionAccessReconciler) inFusionAccessNamespace() builder.WatchesOption {
+	return builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return r.hasFusionAccess(context.Background(), obj.GetNamespace())
+	}))
+}
+
+// hasFusionAccess returns true when the namespace holds a FusionAccess
+func (r *FusionAccessReconciler) hasFusionAccess(ctx context.Context, ns string) bool {
+	fusionAccessList := &fusionv1alpha1.FusionAccessList{}
+	if err := r.List(ctx, fusionAccessList, client.InNamespace(ns)); err != nil {
+		return false
+	}
+	return len(fusionAccessList.Items) > 0
+}
+
+// clusterVersionChanged returns true when the OpenShift version history or the available updates change,
+// ignoring the frequent updates of the ClusterVersion conditions
+func clusterVersionChanged() builder.WatchesOption {
+	return builder.WithPredicates(predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldVersion, ok := e.ObjectOld.(*configv1.ClusterVersion)
+			if !ok {
+				return true
+			}
+			newVersion, ok := e.ObjectNew.(*configv1.ClusterVersion)
+			if !ok {
+				return true
+			}
+			return !reflect.DeepEqual(oldVersion.Status.History, newVersion.Status.History) ||
+				!reflect.DeepEqual(oldVersion.Status.AvailableUpdates, newVersion.Status.AvailableUpdates) ||
+				!reflect.DeepEqual(oldVersion.Status.ConditionalUpdates, newVersion.Status.ConditionalUpdates)
+		},
+	})
+}
+
+// ownedObjectChanged ignores the updates of an object created by the reconciler that only touch its status
+func ownedObjectChanged() builder.WatchesOption {
+	return builder.WithPredicates(predicate.Funcs{UpdateFunc: ownedObjectUpdated})
+}
+
+// ownedObjectUpdated returns true when the spec, the labels or the owners of an owned object change
+func ownedObjectUpdated(e event.UpdateEvent) bool {
+	return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+		!reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) ||
+		!reflect.DeepEqual(e.ObjectOld.GetOwnerReferences(), e.ObjectNew.GetOwnerReferences())
+}
+
 func checkPullSecret(secret *corev1.Secret, ns string) bool {
 	if secret.Type != "Opaque" {
 		return false
@@ -565,12 +862,3 @@ func checkPullSecret(secret *corev1.Secret, ns string) bool {
 	}
 	return true
 }
-
-// func (r *FusionAccessReconciler) finalizeFusionAccess(reqLogger logr.Logger, sc *v1alpha1.FusionAccess) error {
-// 	// TODO(user): Add the cleanup steps that the operator
-// 	// needs to do before the CR can be deleted. Examples
-// 	// of finalizers include performing backups and deleting
-// 	// resources that are not owned by this CR, like a PVC.
-// 	reqLogger.Info("Successfully finalized FusionAccess")
-// 	return nil
-// }
@@ -24,18 +24,26 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	configv1 "github.com/openshift/api/config/v1"
+	consolev1 "github.com/openshift/api/console/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/kubernetes"
 	kubeclient "k8s.io/client-go/kubernetes/fake"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/console"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
 )
 
 const (
@@ -117,9 +125,6 @@ var _ = Describe("FusionAccess Controller", func() {
 				Client:     k8sClient,
 				Scheme:     k8sClient.Scheme(),
 				fullClient: kubeclient.NewSimpleClientset(),
-				CanPullImage: func(ctx context.Context, client kubernetes.Interface, ns, image, pullSecret string) (bool, error) {
-					return true, nil
-				},
 			}
 
 			_, err := FusionAccessReconciler.Reconcile(ctx, reconcile.Request{
@@ -129,6 +134,189 @@ var _ = Describe("FusionAccess Controller", func() {
 			updated := &fusionv1alpha.FusionAccess{}
 			err = k8sClient.Get(ctx, typeNamespacedName, updated)
 			Expect(err).ToNot(HaveOccurred())
+			Expect(updated.Finalizers).To(ContainElement(storageScaleFinalizer))
+		})
+
+		It("should report the state of each component", func() {
+			resource := &fusionv1alpha.FusionAccess{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       resourceName,
+					Namespace:  "default",
+					Generation: 2,
+				},
+				Spec: fusionv1alpha.FusionAccessSpec{
+					StorageScaleVersion: "v5.2.3.1.dev3",
+				},
+			}
+			k8sClient = fakeClientBuilder.WithRuntimeObjects(resource).Build()
+			r := &FusionAccessReconciler{
+				Client:     k8sClient,
+				Scheme:     k8sClient.Scheme(),
+				fullClient: kubeclient.NewSimpleClientset(),
+			}
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).ToNot(HaveOccurred())
+			// The image check pod is polled more often than the IBM operator deployments
+			Expect(result).To(Equal(waitForImagePullCheck))
+
+			updated := &fusionv1alpha.FusionAccess{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+			Expect(updated.Status.ObservedGeneration).To(Equal(updated.Generation))
+			expected := map[string]string{
+				fusionv1alpha.ConditionManifestApply:        fusionv1alpha.ReasonReconcileCompleted,
+				fusionv1alpha.ConditionStorageScaleOperator: fusionv1alpha.ReasonDeploymentsProgressing,
+				fusionv1alpha.ConditionEntitlementSecrets:   fusionv1alpha.ReasonPullSecretMissing,
+				fusionv1alpha.ConditionGlobalPullSecret:     fusionv1alpha.ReasonPullSecretMissing,
+				fusionv1alpha.ConditionKernelModule:         fusionv1alpha.ReasonPullSecretMissing,
+				fusionv1alpha.ConditionImagePull:            fusionv1alpha.ReasonImagePullInProgress,
+				fusionv1alpha.ConditionConsolePlugin:        fusionv1alpha.ReasonReconcileCompleted,
+				fusionv1alpha.ConditionDeviceDiscovery:      fusionv1alpha.ReasonDisabled,
+				fusionv1alpha.ConditionReady:                fusionv1alpha.ReasonComponentsNotReady,
+			}
+			for condType, reason := range expected {
+				cond := meta.FindStatusCondition(updated.Status.Conditions, condType)
+				Expect(cond).ToNot(BeNil(), condType)
+				Expect(cond.Reason).To(Equal(reason), condType)
+				Expect(cond.ObservedGeneration).To(Equal(updated.Generation), condType)
+			}
+			Expect(updated.Status.Status).To(Equal("NotReady"))
+		})
+
+		It("should report a version that is not shipped with the operator", func() {
+			resource := &fusionv1alpha.FusionAccess{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       fusionv1alpha.FusionAccessSpec{StorageScaleVersion: "v1.2.3.4"},
+			}
+			k8sClient = fakeClientBuilder.WithRuntimeObjects(resource).Build()
+			r := &FusionAccessReconciler{
+				Client:     k8sClient,
+				Scheme:     k8sClient.Scheme(),
+				fullClient: kubeclient.NewSimpleClientset(),
+			}
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			updated := &fusionv1alpha.FusionAccess{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+			cond := meta.FindStatusCondition(updated.Status.Conditions, fusionv1alpha.ConditionManifestApply)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonVersionInvalid))
+			Expect(cond.Message).To(ContainSubstring("available versions: v5.2.3.1.dev3"))
+		})
+
+		It("should retry when neither a version nor a manifest URL is set", func() {
+			resource := &fusionv1alpha.FusionAccess{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			}
+			k8sClient = fakeClientBuilder.WithRuntimeObjects(resource).Build()
+			r := &FusionAccessReconciler{
+				Client:     k8sClient,
+				Scheme:     k8sClient.Scheme(),
+				fullClient: kubeclient.NewSimpleClientset(),
+			}
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(MatchError(ContainSubstring("no Storage Scale manifest version")))
+
+			updated := &fusionv1alpha.FusionAccess{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, fusionv1alpha.ConditionManifestApply)).To(BeNil())
+		})
+	})
+
+	Context("When deleting a resource", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		var resource *fusionv1alpha.FusionAccess
+
+		BeforeEach(func() {
+			os.Setenv("DEPLOYMENT_NAMESPACE", "ibm-fusion-access-operator")
+			now := metav1.Now()
+			resource = &fusionv1alpha.FusionAccess{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              resourceName,
+					Namespace:         "default",
+					Finalizers:        []string{storageScaleFinalizer},
+					DeletionTimestamp: &now,
+				},
+				Spec: fusionv1alpha.FusionAccessSpec{
+					StorageScaleVersion: "v5.2.3.1.dev3",
+				},
+			}
+			clusterConsole.Spec.Plugins = []string{"other-plugin", console.PluginName}
+			fakeClientBuilder = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(version, namespace, clusterConsole, clusterPullSecret, resource).
+				WithStatusSubresource(&fusionv1alpha.FusionAccess{})
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("DEPLOYMENT_NAMESPACE")
+		})
+
+		It("should wait while IBM filesystems exist", func() {
+			filesystem := &unstructured.Unstructured{}
+			filesystem.SetGroupVersionKind(utils.IBMFilesystemGVK)
+			filesystem.SetName("fs1")
+			filesystem.SetNamespace("ibm-spectrum-scale")
+			k8sClient = fakeClientBuilder.WithObjects(filesystem).Build()
+
+			reconciler := &FusionAccessReconciler{
+				Client:     k8sClient,
+				Scheme:     k8sClient.Scheme(),
+				fullClient: kubeclient.NewSimpleClientset(),
+			}
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			updated := &fusionv1alpha.FusionAccess{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+			Expect(updated.Finalizers).To(ContainElement(storageScaleFinalizer))
+			cond := meta.FindStatusCondition(updated.Status.Conditions, fusionv1alpha.ConditionUninstalling)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Reason).To(Equal("FilesystemsPresent"))
+			Expect(cond.Message).To(ContainSubstring("ibm-spectrum-scale/fs1"))
+		})
+
+		It("should tear down the resources and remove the finalizer", func() {
+			fullClient := kubeclient.NewSimpleClientset()
+			for _, ns := range IbmEntitlementSecrets("ibm-fusion-access-operator") {
+				_, err := fullClient.CoreV1().Secrets(ns).Create(ctx,
+					newSecret(IBMENTITLEMENTNAME, ns, nil, corev1.SecretTypeDockerConfigJson, nil), metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+			k8sClient = fakeClientBuilder.Build()
+
+			reconciler := &FusionAccessReconciler{
+				Client:     k8sClient,
+				Scheme:     k8sClient.Scheme(),
+				fullClient: fullClient,
+			}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = k8sClient.Get(ctx, typeNamespacedName, &fusionv1alpha.FusionAccess{})
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+
+			updatedConsole := &operatorv1.Console{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "cluster"}, updatedConsole)).To(Succeed())
+			Expect(updatedConsole.Spec.Plugins).To(ConsistOf("other-plugin"))
+
+			for _, ns := range IbmEntitlementSecrets("ibm-fusion-access-operator") {
+				_, err := fullClient.CoreV1().Secrets(ns).Get(ctx, IBMENTITLEMENTNAME, metav1.GetOptions{})
+				Expect(kerrors.IsNotFound(err)).To(BeTrue())
+			}
 		})
 	})
 })
@@ -289,3 +477,52 @@ var _ = Describe("getIbmManifest", func() {
 		})
 	})
 })
+
+var _ = Describe("Owned resources", func() {
+	var fusionaccess *fusionv1alpha.FusionAccess
+
+	BeforeEach(func() {
+		fusionaccess = &fusionv1alpha.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "ibm-fusion-access-operator", UID: "fa-uid"},
+		}
+	})
+
+	It("owns the objects in the namespace of the FusionAccess", func() {
+		lvd := &fusionv1alpha.LocalVolumeDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Name: "auto-discover-devices", Namespace: fusionaccess.Namespace},
+		}
+		Expect(kubeutils.SetOwner(fusionaccess, lvd, createFakeScheme())).To(Succeed())
+		Expect(lvd.Labels).To(HaveKeyWithValue(common.OwnerNameLabel, resourceName))
+		Expect(lvd.Labels).To(HaveKeyWithValue(common.OwnerNamespaceLabel, fusionaccess.Namespace))
+		Expect(lvd.OwnerReferences).To(HaveLen(1))
+		Expect(lvd.OwnerReferences[0].UID).To(Equal(fusionaccess.UID))
+		Expect(*lvd.OwnerReferences[0].Controller).To(BeTrue())
+	})
+
+	It("only labels the cluster-scoped objects", func() {
+		plugin := &consolev1.ConsolePlugin{ObjectMeta: metav1.ObjectMeta{Name: "fusion-access-console"}}
+		Expect(kubeutils.SetOwner(fusionaccess, plugin, createFakeScheme())).To(Succeed())
+		Expect(plugin.Labels).To(HaveKeyWithValue(common.OwnerNameLabel, resourceName))
+		Expect(plugin.OwnerReferences).To(BeEmpty())
+
+		Expect(kubeutils.OwnerRequests(context.Background(), plugin)).To(ConsistOf(reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: resourceName, Namespace: fusionaccess.Namespace},
+		}))
+		Expect(kubeutils.OwnerRequests(context.Background(), &corev1.ConfigMap{})).To(BeEmpty())
+	})
+
+	It("ignores the status updates of the owned objects", func() {
+		old := &fusionv1alpha.LocalVolumeDiscovery{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+		status := old.DeepCopy()
+		status.Status.Phase = fusionv1alpha.Discovering
+		Expect(ownedObjectUpdated(event.UpdateEvent{ObjectOld: old, ObjectNew: status})).To(BeFalse())
+
+		tampered := old.DeepCopy()
+		tampered.Labels = map[string]string{common.OwnerNameLabel: "other"}
+		Expect(ownedObjectUpdated(event.UpdateEvent{ObjectOld: old, ObjectNew: tampered})).To(BeTrue())
+
+		edited := old.DeepCopy()
+		edited.Generation = 2
+		Expect(ownedObjectUpdated(event.UpdateEvent{ObjectOld: old, ObjectNew: edited})).To(BeTrue())
+	})
+})
@@ -0,0 +1,785 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+const (
+	// fileSystemFinalizer removes the StorageClass, the Filesystem and the LocalDisks of a FusionAccessFileSystem
+	fileSystemFinalizer = "fusion.storage.openshift.io/filesystem"
+	// fileSystemProvisioner is the CSI driver of IBM Storage Scale
+	fileSystemProvisioner = "spectrumscale.csi.ibm.com"
+	// fileSystemEventComponent is the name the FusionAccessFileSystem reconciler records its events with
+	fileSystemEventComponent = "fusion-access-filesystem"
+	// fileSystemAllowDeleteLabel must be set on an IBM Filesystem before IBM Storage Scale lets it be deleted
+	fileSystemAllowDeleteLabel = "scale.spectrum.ibm.com/allowDelete"
+)
+
+// Reasons of the events recorded on the FusionAccessFileSystem objects
+const (
+	EventFileSystemResourcesCreated = "ResourcesCreated"
+	EventFileSystemCreateFailed     = "CreateFailed"
+	EventFileSystemDataFound        = "ExistingDataFound"
+	EventFileSystemExpanded         = "FileSystemExpanded"
+	EventFileSystemReady            = "FileSystemReady"
+	EventFileSystemDeleting         = "Deleting"
+)
+
+// errChildTerminating is returned when a resource of the filesystem still exists but is being deleted, for
+// instance the LocalDisks and the Filesystem removed after a failed create, which have finalizers
+var errChildTerminating = errors.New("the resource is being deleted")
+
+// refreshFileSystem is how often the health of a ready filesystem is checked again, as the IBM resources
+// are not watched
+var refreshFileSystem = ctrl.Result{RequeueAfter: 5 * time.Minute}
+
+// FusionAccessFileSystemReconciler creates the IBM LocalDisks, Filesystem and StorageClass of a
+// FusionAccessFileSystem. Until all of them were created once, a failure removes whatever was created
+// so that no orphan is left behind
+type FusionAccessFileSystemReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	recorder *kubeutils.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=fusionaccessfilesystems,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=fusionaccessfilesystems/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=fusionaccessfilesystems/finalizers,verbs=update
+
+// Reconcile creates the resources of a FusionAccessFileSystem and reports their progress
+func (r *FusionAccessFileSystemReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	fs := &fusionv1alpha1.FusionAccessFileSystem{}
+	if err := r.Get(ctx, req.NamespacedName, fs); err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if fs.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(fs, fileSystemFinalizer) {
+			return r.finalizeFileSystem(ctx, fs)
+		}
+		return ctrl.Result{}, nil
+	}
+	// The StorageClass and the Filesystem are named after the object, so two namespaces could claim them
+	if fs.Namespace != IBMCoreNamespace {
+		message := fmt.Sprintf("FusionAccessFileSystems are only handled in the %s namespace", IBMCoreNamespace)
+		setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionResourcesCreated, v1.ConditionFalse,
+			fusionv1alpha1.ReasonUnsupportedNamespace, message)
+		setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionReady, v1.ConditionFalse,
+			fusionv1alpha1.ReasonUnsupportedNamespace, message)
+		return ctrl.Result{}, r.updateFileSystemStatus(ctx, fs)
+	}
+	if !controllerutil.ContainsFinalizer(fs, fileSystemFinalizer) {
+		controllerutil.AddFinalizer(fs, fileSystemFinalizer)
+		if err := r.Update(ctx, fs); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	created, err := r.reconcileResources(ctx, fs)
+	if errors.Is(err, errChildTerminating) {
+		// The IBM resources are not watched, so the reconcile is retried until the resource is gone
+		return waitForComponents, r.updateFileSystemStatus(ctx, fs)
+	}
+	if err != nil {
+		return ctrl.Result{}, errors.Join(err, r.updateFileSystemStatus(ctx, fs))
+	}
+	if !created {
+		// The discovery results are watched, there is nothing to do until the missing disks show up or their
+		// data is acknowledged in the spec
+		return ctrl.Result{}, r.updateFileSystemStatus(ctx, fs)
+	}
+
+	ready, err := r.reconcileFileSystemHealth(ctx, fs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.updateFileSystemStatus(ctx, fs); err != nil {
+		return ctrl.Result{}, err
+	}
+	// Storage Scale adds the new disks to the filesystem in the background
+	if !ready || slices.ContainsFunc(fs.Status.Disks, func(disk fusionv1alpha1.FileSystemDiskStatus) bool {
+		return disk.Phase == fusionv1alpha1.DiskCreated
+	}) {
+		return waitForComponents, nil
+	}
+	return refreshFileSystem, nil
+}
+
+// reconcileResources creates the LocalDisks, the Filesystem and the StorageClass that do not exist, and adds the
+// disks added to the spec later to the Filesystem. It returns false when the filesystem was not created yet
+// because some disks cannot be used, in which case nothing is created. Once the filesystem exists, such disks
+// are left out and reported in the DisksAdded condition, while the other new disks are added
+func (r *FusionAccessFileSystemReconciler) reconcileResources(ctx context.Context, fs *fusionv1alpha1.FusionAccessFileSystem) (bool, error) {
+	wasCreated := meta.IsStatusConditionTrue(fs.Status.Conditions, fusionv1alpha1.FileSystemConditionResourcesCreated)
+	disks, results, err := r.resolveDisks(ctx, fs)
+	if err != nil {
+		return false, err
+	}
+	fs.Status.Disks = disks
+	reason, message, err := r.checkNewDisks(ctx, fs, results)
+	if err != nil {
+		return false, err
+	}
+	usable := fs.Status.Disks
+	if reason != "" {
+		if !wasCreated {
+			setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionResourcesCreated, v1.ConditionFalse, reason, message)
+			setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionReady, v1.ConditionFalse, reason,
+				"Waiting for all the disks to be usable")
+			return false, nil
+		}
+		setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionDisksAdded, v1.ConditionFalse, reason, message)
+		usable = slices.DeleteFunc(slices.Clone(usable), func(disk fusionv1alpha1.FileSystemDiskStatus) bool {
+			return disk.Message != ""
+		})
+	}
+
+	children, err := r.fileSystemResources(fs, usable)
+	if err != nil {
+		return false, err
+	}
+	for _, child := range children {
+		if err := r.createChild(ctx, fs, child); err != nil {
+			if errors.Is(err, errChildTerminating) {
+				message := fmt.Sprintf("Waiting for %s %s to be deleted before creating it again",
+					child.GetObjectKind().GroupVersionKind().Kind, child.GetName())
+				setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionResourcesCreated, v1.ConditionFalse,
+					fusionv1alpha1.ReasonResourcesTerminating, message)
+				setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionReady, v1.ConditionFalse,
+					fusionv1alpha1.ReasonResourcesTerminating, message)
+				return false, err
+			}
+			if wasCreated {
+				return false, err
+			}
+			// Nothing was ever complete, so everything that exists is a leftover of this attempt
+			message := fmt.Sprintf("Failed to create %s %s: %v", child.GetObjectKind().GroupVersionKind().Kind, child.GetName(), err)
+			if rerr := r.removeResources(ctx, fs, children); rerr != nil {
+				message = fmt.Sprintf("%s. Removing the resources created so far failed too: %v", message, rerr)
+			}
+			r.recorder.Event(fs, corev1.EventTypeWarning, EventFileSystemCreateFailed, message)
+			setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionResourcesCreated, v1.ConditionFalse,
+				fusionv1alpha1.ReasonCreateFailed, message)
+			setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionReady, v1.ConditionFalse,
+				fusionv1alpha1.ReasonCreateFailed, message)
+			return false, err
+		}
+	}
+
+	if wasCreated {
+		// The Filesystem exists already, so the new LocalDisks are added to its pools
+		added, err := r.addFileSystemDisks(ctx, fs, usable)
+		if err != nil {
+			return false, err
+		}
+		if len(added) > 0 {
+			log.Log.Info("Added disks to the filesystem", "filesystem", fs.Name, "namespace", fs.Namespace, "localDisks", added)
+			r.recorder.Eventf(fs, corev1.EventTypeNormal, EventFileSystemExpanded,
+				"Added the LocalDisks %s to the filesystem", strings.Join(added, ", "))
+		}
+	} else {
+		log.Log.Info("Created the filesystem resources", "filesystem", fs.Name, "namespace", fs.Namespace)
+		r.recorder.Eventf(fs, corev1.EventTypeNormal, EventFileSystemResourcesCreated,
+			"Created %d LocalDisks, the Filesystem and the StorageClass", len(usable))
+	}
+	fs.Status.StorageClassName = fs.Name
+	setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionResourcesCreated, v1.ConditionTrue,
+		fusionv1alpha1.ReasonResourcesCreated, "The LocalDisks, the Filesystem and the StorageClass were created")
+	for i := range fs.Status.Disks {
+		if fs.Status.Disks[i].Phase == fusionv1alpha1.DiskPending && fs.Status.Disks[i].Message == "" {
+			fs.Status.Disks[i].Phase = fusionv1alpha1.DiskCreated
+		}
+	}
+	if reason == "" {
+		setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionDisksAdded, v1.ConditionTrue,
+			fusionv1alpha1.ReasonDisksAdded, "All the disks were added to the filesystem")
+	}
+	return true, nil
+}
+
+// resolveDisks finds the node and the device path of each WWN in the LocalVolumeDiscoveryResults, which it
+// returns as well. The disks whose LocalDisk was created keep their node and device, as the discovery reports
+// the data Storage Scale writes on them or may stop reporting them at all. A shared LUN is visible on several
+// nodes, the first one by name is used
+func (r *FusionAccessFileSystemReconciler) resolveDisks(
+	ctx context.Context,
+	fs *fusionv1alpha1.FusionAccessFileSystem,
+) ([]fusionv1alpha1.FileSystemDiskStatus, []fusionv1alpha1.LocalVolumeDiscoveryResult, error) {
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return nil, nil, err
+	}
+	results := &fusionv1alpha1.LocalVolumeDiscoveryResultList{}
+	if err := r.List(ctx, results, client.InNamespace(ns)); err != nil {
+		return nil, nil, fmt.Errorf("failed to list the device discovery results: %w", err)
+	}
+	slices.SortFunc(results.Items, func(a, b fusionv1alpha1.LocalVolumeDiscoveryResult) int {
+		return strings.Compare(a.Spec.NodeName, b.Spec.NodeName)
+	})
+
+	disks := make([]fusionv1alpha1.FileSystemDiskStatus, 0, len(fs.Spec.Disks))
+	for _, wwn := range fs.Spec.Disks {
+		idx := slices.IndexFunc(fs.Status.Disks, func(disk fusionv1alpha1.FileSystemDiskStatus) bool {
+			return disk.WWN == wwn && (disk.Phase == fusionv1alpha1.DiskCreated || disk.Phase == fusionv1alpha1.DiskInUse)
+		})
+		if idx >= 0 {
+			disks = append(disks, fs.Status.Disks[idx])
+			continue
+		}
+		disk := fusionv1alpha1.FileSystemDiskStatus{WWN: wwn, Phase: fusionv1alpha1.DiskPending}
+	results:
+		for _, result := range results.Items {
+			for _, device := range result.Status.DiscoveredDevices {
+				if device.WWN == wwn {
+					disk.Node = result.Spec.NodeName
+					disk.Device = device.Path
+					disk.Size = device.Size
+					disk.LocalDisk = localDiskName(device)
+					disk.ExistingData = device.ExistingData
+					break results
+				}
+			}
+		}
+		disks = append(disks, disk)
+	}
+	return disks, results.Items, nil
+}
+
+// checkNewDisks marks the disks that were not created yet and cannot be used, with the reason in their message.
+// They must be discovered on all the storage nodes, as the filesystem is shared, and hold no data unless it
+// is acknowledged. It returns the reason and the message of the DisksAdded condition, empty if all the disks
+// can be used
+func (r *FusionAccessFileSystemReconciler) checkNewDisks(
+	ctx context.Context,
+	fs *fusionv1alpha1.FusionAccessFileSystem,
+	results []fusionv1alpha1.LocalVolumeDiscoveryResult,
+) (string, string, error) {
+	if !slices.ContainsFunc(fs.Status.Disks, func(disk fusionv1alpha1.FileSystemDiskStatus) bool {
+		return disk.Phase == fusionv1alpha1.DiskPending
+	}) {
+		return "", "", nil
+	}
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, client.MatchingLabels{common.StorageRoleLabel: common.StorageRoleValue}); err != nil {
+		return "", "", fmt.Errorf("failed to list the storage nodes: %w", err)
+	}
+
+	notFound, notShared := []string{}, []string{}
+	for i := range fs.Status.Disks {
+		disk := &fs.Status.Disks[i]
+		if disk.Phase != fusionv1alpha1.DiskPending {
+			continue
+		}
+		if disk.LocalDisk == "" {
+			disk.Message = "The device discovery did not find the disk"
+			notFound = append(notFound, disk.WWN)
+			continue
+		}
+		if len(nodes.Items) == 0 {
+			disk.Phase = fusionv1alpha1.DiskNotShared
+			disk.Message = "No node is labelled as a storage node"
+			continue
+		}
+		missing := []string{}
+		for _, node := range nodes.Items {
+			if !isDiscoveredOn(results, node.Name, disk.WWN) {
+				missing = append(missing, node.Name)
+			}
+		}
+		if len(missing) > 0 {
+			slices.Sort(missing)
+			disk.Phase = fusionv1alpha1.DiskNotShared
+			disk.Message = fmt.Sprintf("Not visible on the storage nodes %s", strings.Join(missing, ", "))
+			notShared = append(notShared, fmt.Sprintf("%s (not on %s)", disk.WWN, strings.Join(missing, ", ")))
+		}
+	}
+	blocked := checkExistingData(fs)
+
+	reasons, messages := []string{}, []string{}
+	if len(notFound) > 0 {
+		reasons = append(reasons, fusionv1alpha1.ReasonDisksNotDiscovered)
+		messages = append(messages, fmt.Sprintf("The device discovery did not find the disks: %s", strings.Join(notFound, ", ")))
+	}
+	if len(nodes.Items) == 0 {
+		reasons = append(reasons, fusionv1alpha1.ReasonDisksNotShared)
+		messages = append(messages, "No node is labelled as a storage node")
+	} else if len(notShared) > 0 {
+		reasons = append(reasons, fusionv1alpha1.ReasonDisksNotShared)
+		messages = append(messages, fmt.Sprintf("The disks are not visible on all the storage nodes: %s", strings.Join(notShared, ", ")))
+	}
+	if len(blocked) > 0 {
+		message := fmt.Sprintf("The disks hold existing data: %s. List them in spec.wipeExistingData to destroy it",
+			strings.Join(blocked, ", "))
+		r.recorder.Event(fs, corev1.EventTypeWarning, EventFileSystemDataFound, message)
+		reasons = append(reasons, fusionv1alpha1.ReasonDisksContainData)
+		messages = append(messages, message)
+	}
+	if len(reasons) == 0 {
+		return "", "", nil
+	}
+	return reasons[0], strings.Join(messages, ". "), nil
+}
+
+// isDiscoveredOn returns true if the device discovery found the WWN on the node
+func isDiscoveredOn(results []fusionv1alpha1.LocalVolumeDiscoveryResult, nodeName, wwn string) bool {
+	return slices.ContainsFunc(results, func(result fusionv1alpha1.LocalVolumeDiscoveryResult) bool {
+		return result.Spec.NodeName == nodeName &&
+			slices.ContainsFunc(result.Status.DiscoveredDevices, func(device fusionv1alpha1.DiscoveredDevice) bool {
+				return device.WWN == wwn
+			})
+	})
+}
+
+// checkExistingData marks the disks that are not created yet and hold data that is not listed in
+// spec.wipeExistingData, and returns them with the signatures found on them
+func checkExistingData(fs *fusionv1alpha1.FusionAccessFileSystem) []string {
+	blocked := []string{}
+	for i := range fs.Status.Disks {
+		disk := &fs.Status.Disks[i]
+		if disk.Phase != fusionv1alpha1.DiskPending || len(disk.ExistingData) == 0 ||
+			slices.Contains(fs.Spec.WipeExistingData, disk.WWN) {
+			continue
+		}
+		disk.Phase = fusionv1alpha1.DiskDataFound
+		disk.Message = fmt.Sprintf("The disk holds existing data: %s", strings.Join(disk.ExistingData, ", "))
+		blocked = append(blocked, fmt.Sprintf("%s (%s)", disk.WWN, strings.Join(disk.ExistingData, ", ")))
+	}
+	return blocked
+}
+
+// localDiskName is the name of the LocalDisk of a device, the same the console creates it with
+func localDiskName(device fusionv1alpha1.DiscoveredDevice) string {
+	name := fmt.Sprintf("%s-%s", strings.TrimPrefix(device.Path, "/dev/"), device.WWN)
+	return strings.ToLower(strings.ReplaceAll(name, ".", "-"))
+}
+
+// fileSystemResources returns the LocalDisks of the disks, the Filesystem and the StorageClass of the filesystem,
+// in the order they are created. The LocalDisks and the Filesystem are in the IBM namespace. Storage Scale only
+// overwrites the existing data of the disks listed in spec.wipeExistingData
+func (r *FusionAccessFileSystemReconciler) fileSystemResources(
+	fs *fusionv1alpha1.FusionAccessFileSystem,
+	disks []fusionv1alpha1.FileSystemDiskStatus,
+) ([]client.Object, error) {
+	children := []client.Object{}
+	diskNames := []any{}
+	for _, disk := range disks {
+		if disk.LocalDisk == "" {
+			continue
+		}
+		localDisk := &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{
+				"device": disk.Device,
+				"node":   disk.Node,
+			},
+		}}
+		if slices.Contains(fs.Spec.WipeExistingData, disk.WWN) {
+			if err := unstructured.SetNestedField(localDisk.Object, true, "spec", "existingDataSkipVerify"); err != nil {
+				return nil, err
+			}
+		}
+		localDisk.SetGroupVersionKind(utils.IBMLocalDiskGVK)
+		localDisk.SetName(disk.LocalDisk)
+		localDisk.SetNamespace(IBMCoreNamespace)
+		children = append(children, localDisk)
+		diskNames = append(diskNames, disk.LocalDisk)
+	}
+
+	replication := fs.Spec.Replication
+	if replication == "" {
+		replication = "1-way"
+	}
+	filesystem := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"local": map[string]any{
+				"pools":       []any{map[string]any{"disks": diskNames}},
+				"replication": replication,
+				"type":        "shared",
+			},
+		},
+	}}
+	filesystem.SetGroupVersionKind(utils.IBMFilesystemGVK)
+	filesystem.SetName(fs.Name)
+	filesystem.SetNamespace(IBMCoreNamespace)
+	children = append(children, filesystem)
+
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta:           v1.ObjectMeta{Name: fs.Name},
+		Provisioner:          fileSystemProvisioner,
+		Parameters:           map[string]string{"volBackendFs": fs.Name},
+		ReclaimPolicy:        ptr.To(corev1.PersistentVolumeReclaimDelete),
+		AllowVolumeExpansion: ptr.To(true),
+		VolumeBindingMode:    ptr.To(storagev1.VolumeBindingImmediate),
+	}
+	storageClass.SetGroupVersionKind(storagev1.SchemeGroupVersion.WithKind("StorageClass"))
+	children = append(children, storageClass)
+
+	for _, child := range children {
+		if err := kubeutils.SetOwner(fs, child, r.Scheme); err != nil {
+			return nil, err
+		}
+	}
+	return children, nil
+}
+
+// createChild creates a resource of the filesystem unless it exists already. An existing resource that
+// belongs to something else is never taken over, and one that is being deleted returns errChildTerminating
+func (r *FusionAccessFileSystemReconciler) createChild(ctx context.Context, fs *fusionv1alpha1.FusionAccessFileSystem, child client.Object) error {
+	err := r.Create(ctx, child)
+	if err == nil || !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+	existing, ok := child.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected type %T", child)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(child), existing); err != nil {
+		return err
+	}
+	if !isOwnedByFileSystem(existing, fs) {
+		return fmt.Errorf("it already exists and does not belong to FusionAccessFileSystem %s/%s", fs.Namespace, fs.Name)
+	}
+	if existing.GetDeletionTimestamp() != nil {
+		return fmt.Errorf("%w: %s", errChildTerminating, existing.GetName())
+	}
+	return nil
+}
+
+// removeResources deletes the resources of the filesystem in the reverse order of their creation
+func (r *FusionAccessFileSystemReconciler) removeResources(
+	ctx context.Context,
+	fs *fusionv1alpha1.FusionAccessFileSystem,
+	children []client.Object,
+) error {
+	errs := []error{}
+	for _, child := range slices.Backward(children) {
+		if _, err := r.removeChild(ctx, fs, child); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// removeChild deletes a resource of the filesystem if it exists and belongs to it. It returns true if the
+// resource is gone
+func (r *FusionAccessFileSystemReconciler) removeChild(
+	ctx context.Context,
+	fs *fusionv1alpha1.FusionAccessFileSystem,
+	child client.Object,
+) (bool, error) {
+	existing, ok := child.DeepCopyObject().(client.Object)
+	if !ok {
+		return false, fmt.Errorf("unexpected type %T", child)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(child), existing); err != nil {
+		if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if !isOwnedByFileSystem(existing, fs) {
+		return true, nil
+	}
+	if existing.GetDeletionTimestamp() != nil {
+		return false, nil
+	}
+	if _, ok := existing.GetLabels()[fileSystemAllowDeleteLabel]; !ok && child.GetObjectKind().GroupVersionKind() == utils.IBMFilesystemGVK {
+		patch := client.MergeFrom(existing.DeepCopyObject().(client.Object))
+		labels := existing.GetLabels()
+		labels[fileSystemAllowDeleteLabel] = ""
+		existing.SetLabels(labels)
+		if err := r.Patch(ctx, existing, patch); err != nil {
+			return false, fmt.Errorf("failed to allow the deletion of %s: %w", existing.GetName(), err)
+		}
+	}
+	if err := r.Delete(ctx, existing); err != nil && !kerrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete %s: %w", existing.GetName(), err)
+	}
+	return false, nil
+}
+
+// addFileSystemDisks adds the LocalDisks of the disks that are missing from the pools of the Filesystem to its
+// first pool, and returns their names
+func (r *FusionAccessFileSystemReconciler) addFileSystemDisks(
+	ctx context.Context,
+	fs *fusionv1alpha1.FusionAccessFileSystem,
+	disks []fusionv1alpha1.FileSystemDiskStatus,
+) ([]string, error) {
+	filesystem := &unstructured.Unstructured{}
+	filesystem.SetGroupVersionKind(utils.IBMFilesystemGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: fs.Name, Namespace: IBMCoreNamespace}, filesystem); err != nil {
+		return nil, err
+	}
+	patch := client.MergeFrom(filesystem.DeepCopy())
+	pools, _, err := unstructured.NestedSlice(filesystem.Object, "spec", "local", "pools")
+	if err != nil {
+		return nil, err
+	}
+	present := []any{}
+	for _, pool := range pools {
+		if pool, ok := pool.(map[string]any); ok {
+			poolDisks, _, _ := unstructured.NestedSlice(pool, "disks")
+			present = append(present, poolDisks...)
+		}
+	}
+	added := []string{}
+	for _, disk := range disks {
+		if disk.LocalDisk != "" && !slices.Contains(present, any(disk.LocalDisk)) {
+			added = append(added, disk.LocalDisk)
+		}
+	}
+	if len(added) == 0 {
+		return added, nil
+	}
+
+	if len(pools) == 0 {
+		pools = []any{map[string]any{}}
+	}
+	first, ok := pools[0].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected pool in Filesystem %s: %v", fs.Name, pools[0])
+	}
+	firstDisks, _, _ := unstructured.NestedSlice(first, "disks")
+	for _, name := range added {
+		firstDisks = append(firstDisks, name)
+	}
+	first["disks"] = firstDisks
+	pools[0] = first
+	if err := unstructured.SetNestedSlice(filesystem.Object, pools, "spec", "local", "pools"); err != nil {
+		return nil, err
+	}
+	if err := r.Patch(ctx, filesystem, patch); err != nil {
+		return nil, fmt.Errorf("failed to add disks to Filesystem %s: %w", fs.Name, err)
+	}
+	return added, nil
+}
+
+// isOwnedByFileSystem returns true if the resource was created for the FusionAccessFileSystem
+func isOwnedByFileSystem(obj client.Object, fs *fusionv1alpha1.FusionAccessFileSystem) bool {
+	return obj.GetLabels()[common.OwnerNameLabel] == fs.Name && obj.GetLabels()[common.OwnerNamespaceLabel] == fs.Namespace
+}
+
+// reconcileFileSystemHealth reports the progress of each created disk and the capacity of the disks in use, and
+// mirrors the health of the IBM Filesystem in the Ready condition. It returns true when the filesystem is healthy
+func (r *FusionAccessFileSystemReconciler) reconcileFileSystemHealth(ctx context.Context, fs *fusionv1alpha1.FusionAccessFileSystem) (bool, error) {
+	var capacity int64
+	for i := range fs.Status.Disks {
+		disk := &fs.Status.Disks[i]
+		if disk.Phase != fusionv1alpha1.DiskCreated && disk.Phase != fusionv1alpha1.DiskInUse {
+			continue
+		}
+		localDisk := &unstructured.Unstructured{}
+		localDisk.SetGroupVersionKind(utils.IBMLocalDiskGVK)
+		if err := r.Get(ctx, types.NamespacedName{Name: disk.LocalDisk, Namespace: IBMCoreNamespace}, localDisk); err != nil {
+			return false, err
+		}
+		disk.Phase = fusionv1alpha1.DiskCreated
+		if filesystem, _, _ := unstructured.NestedString(localDisk.Object, "status", "filesystem"); filesystem == fs.Name {
+			disk.Phase = fusionv1alpha1.DiskInUse
+			capacity += disk.Size
+		}
+	}
+	fs.Status.Capacity = resource.NewQuantity(capacity, resource.BinarySI)
+
+	filesystem := &unstructured.Unstructured{}
+	filesystem.SetGroupVersionKind(utils.IBMFilesystemGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: fs.Name, Namespace: IBMCoreNamespace}, filesystem); err != nil {
+		return false, err
+	}
+	pending, failed, message := ibmHealth(filesystem)
+	switch {
+	case failed != "":
+		setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionReady, v1.ConditionFalse,
+			fusionv1alpha1.ReasonFileSystemUnhealthy, fmt.Sprintf("The filesystem is not %s: %s", failed, message))
+		return false, nil
+	case len(pending) > 0:
+		setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionReady, v1.ConditionFalse,
+			fusionv1alpha1.ReasonFileSystemProgressing,
+			fmt.Sprintf("Waiting for the filesystem conditions: %s", strings.Join(pending, ", ")))
+		return false, nil
+	}
+	if !meta.IsStatusConditionTrue(fs.Status.Conditions, fusionv1alpha1.FileSystemConditionReady) {
+		r.recorder.Event(fs, corev1.EventTypeNormal, EventFileSystemReady, "The filesystem is healthy")
+	}
+	setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionReady, v1.ConditionTrue,
+		fusionv1alpha1.ReasonFileSystemHealthy, "The filesystem is healthy")
+	return true, nil
+}
+
+// finalizeFileSystem removes the StorageClass, the Filesystem and then the LocalDisks. It waits as long as
+// persistent volumes of its StorageClass exist, as removing the filesystem would destroy their data
+func (r *FusionAccessFileSystemReconciler) finalizeFileSystem(ctx context.Context, fs *fusionv1alpha1.FusionAccessFileSystem) (ctrl.Result, error) {
+	inUse, err := r.fileSystemVolumes(ctx, fs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(inUse) > 0 {
+		setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionReady, v1.ConditionFalse, fusionv1alpha1.ReasonVolumesExist,
+			fmt.Sprintf("The deletion is blocked until the following persistent volumes are deleted: %s", strings.Join(inUse, ", ")))
+		return waitForComponents, r.updateFileSystemStatus(ctx, fs)
+	}
+
+	if !meta.IsStatusConditionPresentAndEqual(fs.Status.Conditions, fusionv1alpha1.FileSystemConditionReady, v1.ConditionFalse) ||
+		meta.FindStatusCondition(fs.Status.Conditions, fusionv1alpha1.FileSystemConditionReady).Reason != fusionv1alpha1.ReasonDeleting {
+		r.recorder.Event(fs, corev1.EventTypeNormal, EventFileSystemDeleting, "Removing the StorageClass, the Filesystem and the LocalDisks")
+	}
+	setFileSystemCondition(fs, fusionv1alpha1.FileSystemConditionReady, v1.ConditionFalse, fusionv1alpha1.ReasonDeleting,
+		"Removing the StorageClass, the Filesystem and the LocalDisks")
+	if err := r.updateFileSystemStatus(ctx, fs); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	children, err := r.fileSystemResources(fs, fs.Status.Disks)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// The LocalDisks can only be removed once the Filesystem using them is gone
+	localDisks, others := []client.Object{}, []client.Object{}
+	for _, child := range children {
+		if child.GetObjectKind().GroupVersionKind() == utils.IBMLocalDiskGVK {
+			localDisks = append(localDisks, child)
+		} else {
+			others = append(others, child)
+		}
+	}
+	for _, group := range [][]client.Object{others, localDisks} {
+		gone := true
+		for _, child := range slices.Backward(group) {
+			removed, err := r.removeChild(ctx, fs, child)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			gone = gone && removed
+		}
+		if !gone {
+			return waitForComponents, nil
+		}
+	}
+
+	controllerutil.RemoveFinalizer(fs, fileSystemFinalizer)
+	return ctrl.Result{}, r.Update(ctx, fs)
+}
+
+// fileSystemVolumes returns the persistent volumes of the StorageClass of the filesystem. A StorageClass of the
+// same name that belongs to something else does not hold its volumes
+func (r *FusionAccessFileSystemReconciler) fileSystemVolumes(ctx context.Context, fs *fusionv1alpha1.FusionAccessFileSystem) ([]string, error) {
+	storageClass := &storagev1.StorageClass{}
+	if err := r.Get(ctx, types.NamespacedName{Name: fs.Name}, storageClass); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !isOwnedByFileSystem(storageClass, fs) {
+		return nil, nil
+	}
+
+	volumes := &corev1.PersistentVolumeList{}
+	if err := r.List(ctx, volumes); err != nil {
+		return nil, fmt.Errorf("failed to list persistent volumes: %w", err)
+	}
+	inUse := []string{}
+	for _, volume := range volumes.Items {
+		if volume.Spec.StorageClassName == fs.Name {
+			inUse = append(inUse, volume.Name)
+		}
+	}
+	return inUse, nil
+}
+
+// setFileSystemCondition sets a condition for the current generation of the FusionAccessFileSystem object
+func setFileSystemCondition(fs *fusionv1alpha1.FusionAccessFileSystem, condType string, status v1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&fs.Status.Conditions, v1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: fs.Generation,
+	})
+}
+
+// updateFileSystemStatus writes the status of the FusionAccessFileSystem object
+func (r *FusionAccessFileSystemReconciler) updateFileSystemStatus(ctx context.Context, fs *fusionv1alpha1.FusionAccessFileSystem) error {
+	fs.Status.ObservedGeneration = fs.Generation
+	return r.Status().Update(ctx, fs)
+}
+
+// getPendingFileSystemRequests enqueues the FusionAccessFileSystems whose disks were not all added yet, as
+// they may be waiting for the disks to be discovered
+func (r *FusionAccessFileSystemReconciler) getPendingFileSystemRequests(ctx context.Context, _ client.Object) []reconcile.Request {
+	filesystems := &fusionv1alpha1.FusionAccessFileSystemList{}
+	if err := r.List(ctx, filesystems); err != nil {
+		return []reconcile.Request{}
+	}
+	requests := []reconcile.Request{}
+	for _, fs := range filesystems.Items {
+		if !meta.IsStatusConditionTrue(fs.Status.Conditions, fusionv1alpha1.FileSystemConditionDisksAdded) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&fs)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FusionAccessFileSystemReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = kubeutils.NewEventRecorder(mgr.GetEventRecorderFor(fileSystemEventComponent), kubeutils.DefaultEventInterval)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&fusionv1alpha1.FusionAccessFileSystem{}).
+		Watches(
+			&fusionv1alpha1.LocalVolumeDiscoveryResult{},
+			handler.EnqueueRequestsFromMapFunc(r.getPendingFileSystemRequests),
+		).
+		// The StorageClass is put back when it is deleted. The IBM resources are not watched, as their CRDs
+		// may not exist when the operator starts
+		Watches(
+			&storagev1.StorageClass{},
+			handler.EnqueueRequestsFromMapFunc(kubeutils.OwnerRequests),
+			ownedObjectChanged(),
+		).
+		Complete(r)
+}
@@ -0,0 +1,515 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+var _ = Describe("FusionAccessFileSystem Controller", func() {
+	var (
+		ctx      = context.Background()
+		recorder *record.FakeRecorder
+		r        *FusionAccessFileSystemReconciler
+		fs       *fusionv1alpha1.FusionAccessFileSystem
+	)
+
+	const (
+		operatorNamespace = "ibm-fusion-access-operator"
+		fsName            = "shared-fs"
+	)
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: fsName, Namespace: IBMCoreNamespace}}
+
+	discoveryResult := func(node string, devices ...fusionv1alpha1.DiscoveredDevice) *fusionv1alpha1.LocalVolumeDiscoveryResult {
+		return &fusionv1alpha1.LocalVolumeDiscoveryResult{
+			ObjectMeta: metav1.ObjectMeta{Name: "discovery-result-" + node, Namespace: operatorNamespace},
+			Spec:       fusionv1alpha1.LocalVolumeDiscoveryResultSpec{NodeName: node},
+			Status:     fusionv1alpha1.LocalVolumeDiscoveryResultStatus{DiscoveredDevices: devices},
+		}
+	}
+
+	storageNode := func(name string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{common.StorageRoleLabel: common.StorageRoleValue},
+		}}
+	}
+
+	// worker-0 is a storage node in every test
+	setup := func(objs ...client.Object) {
+		objs = append(objs, storageNode("worker-0"))
+		recorder = record.NewFakeRecorder(10)
+		fr := newFakeReconciler(objs, withStatusSubresource(&fusionv1alpha1.FusionAccessFileSystem{}), withRecorder(recorder))
+		r = &FusionAccessFileSystemReconciler{Client: fr.Client, Scheme: fr.Scheme, recorder: fr.recorder}
+	}
+
+	getFileSystem := func() *fusionv1alpha1.FusionAccessFileSystem {
+		current := &fusionv1alpha1.FusionAccessFileSystem{}
+		Expect(r.Get(ctx, request.NamespacedName, current)).To(Succeed())
+		return current
+	}
+
+	getIBM := func(gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		return obj, r.Get(ctx, types.NamespacedName{Name: name, Namespace: IBMCoreNamespace}, obj)
+	}
+
+	condition := func(condType string) *metav1.Condition {
+		return meta.FindStatusCondition(getFileSystem().Status.Conditions, condType)
+	}
+
+	BeforeEach(func() {
+		os.Setenv("DEPLOYMENT_NAMESPACE", operatorNamespace)
+		fs = &fusionv1alpha1.FusionAccessFileSystem{
+			ObjectMeta: metav1.ObjectMeta{Name: fsName, Namespace: IBMCoreNamespace},
+			Spec: fusionv1alpha1.FusionAccessFileSystemSpec{
+				Disks:       []string{"uuid.6000c29a", "uuid.6000c29b"},
+				Replication: "1-way",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("DEPLOYMENT_NAMESPACE")
+	})
+
+	It("waits for all the disks to be discovered before creating anything", func() {
+		setup(fs, discoveryResult("worker-0", fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdb", WWN: "uuid.6000c29a"}))
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		cond := condition(fusionv1alpha1.FileSystemConditionResourcesCreated)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha1.ReasonDisksNotDiscovered))
+		Expect(cond.Message).To(ContainSubstring("uuid.6000c29b"))
+		Expect(getFileSystem().Finalizers).To(ContainElement(fileSystemFinalizer))
+		_, err = getIBM(utils.IBMLocalDiskGVK, "sdb-uuid-6000c29a")
+		Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("creates the LocalDisks, the Filesystem and the StorageClass", func() {
+		setup(fs,
+			discoveryResult("worker-1",
+				fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdc", WWN: "uuid.6000c29a"},
+				fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdd", WWN: "uuid.6000c29b"}),
+			discoveryResult("worker-0",
+				fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdb", WWN: "uuid.6000c29a"},
+				fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdc", WWN: "uuid.6000c29b"}))
+		result, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(waitForComponents))
+
+		localDisk, err := getIBM(utils.IBMLocalDiskGVK, "sdb-uuid-6000c29a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(localDisk.Object["spec"]).To(Equal(map[string]any{"device": "/dev/sdb", "node": "worker-0"}))
+		Expect(localDisk.GetLabels()).To(HaveKeyWithValue(common.OwnerNameLabel, fsName))
+
+		filesystem, err := getIBM(utils.IBMFilesystemGVK, fsName)
+		Expect(err).ToNot(HaveOccurred())
+		pools, _, _ := unstructured.NestedSlice(filesystem.Object, "spec", "local", "pools")
+		Expect(pools).To(Equal([]any{map[string]any{"disks": []any{"sdb-uuid-6000c29a", "sdc-uuid-6000c29b"}}}))
+
+		storageClass := &storagev1.StorageClass{}
+		Expect(r.Get(ctx, types.NamespacedName{Name: fsName}, storageClass)).To(Succeed())
+		Expect(storageClass.Provisioner).To(Equal(fileSystemProvisioner))
+		Expect(storageClass.Parameters).To(HaveKeyWithValue("volBackendFs", fsName))
+
+		current := getFileSystem()
+		Expect(current.Status.StorageClassName).To(Equal(fsName))
+		Expect(current.Status.Disks).To(HaveLen(2))
+		Expect(current.Status.Disks[1].Node).To(Equal("worker-0"))
+		Expect(current.Status.Disks[1].Phase).To(Equal(fusionv1alpha1.DiskCreated))
+		Expect(condition(fusionv1alpha1.FileSystemConditionResourcesCreated).Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition(fusionv1alpha1.FileSystemConditionReady).Reason).To(Equal(fusionv1alpha1.ReasonFileSystemProgressing))
+		Expect(<-recorder.Events).To(Equal("Normal ResourcesCreated Created 2 LocalDisks, the Filesystem and the StorageClass"))
+	})
+
+	It("reports the filesystem ready once IBM Storage Scale reports it healthy", func() {
+		setup(fs, discoveryResult("worker-0",
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdb", WWN: "uuid.6000c29a"},
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdc", WWN: "uuid.6000c29b"}))
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		filesystem, err := getIBM(utils.IBMFilesystemGVK, fsName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unstructured.SetNestedSlice(filesystem.Object, []any{
+			map[string]any{"type": "Success", "status": "True"},
+			map[string]any{"type": "Healthy", "status": "True"},
+		}, "status", "conditions")).To(Succeed())
+		Expect(r.Update(ctx, filesystem)).To(Succeed())
+		localDisk, err := getIBM(utils.IBMLocalDiskGVK, "sdb-uuid-6000c29a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unstructured.SetNestedField(localDisk.Object, fsName, "status", "filesystem")).To(Succeed())
+		Expect(r.Update(ctx, localDisk)).To(Succeed())
+
+		// The filesystem is polled until Storage Scale uses all the disks
+		result, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(waitForComponents))
+		Expect(condition(fusionv1alpha1.FileSystemConditionReady).Status).To(Equal(metav1.ConditionTrue))
+		Expect(getFileSystem().Status.Disks[0].Phase).To(Equal(fusionv1alpha1.DiskInUse))
+		Expect(getFileSystem().Status.Disks[1].Phase).To(Equal(fusionv1alpha1.DiskCreated))
+
+		localDisk, err = getIBM(utils.IBMLocalDiskGVK, "sdc-uuid-6000c29b")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unstructured.SetNestedField(localDisk.Object, fsName, "status", "filesystem")).To(Succeed())
+		Expect(r.Update(ctx, localDisk)).To(Succeed())
+		result, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(refreshFileSystem))
+	})
+
+	It("removes what it created when a resource cannot be created", func() {
+		foreign := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: fsName}, Provisioner: "example.com/other"}
+		setup(fs, foreign, discoveryResult("worker-0",
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdb", WWN: "uuid.6000c29a"},
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdc", WWN: "uuid.6000c29b"}))
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).To(HaveOccurred())
+
+		_, err = getIBM(utils.IBMLocalDiskGVK, "sdb-uuid-6000c29a")
+		Expect(kerrors.IsNotFound(err)).To(BeTrue())
+		_, err = getIBM(utils.IBMFilesystemGVK, fsName)
+		Expect(kerrors.IsNotFound(err)).To(BeTrue())
+		Expect(r.Get(ctx, types.NamespacedName{Name: fsName}, &storagev1.StorageClass{})).To(Succeed())
+		Expect(condition(fusionv1alpha1.FileSystemConditionResourcesCreated).Reason).To(Equal(fusionv1alpha1.ReasonCreateFailed))
+		Expect(<-recorder.Events).To(ContainSubstring("Warning CreateFailed Failed to create StorageClass shared-fs"))
+	})
+
+	It("creates the resources again once the ones removed after a failure are gone", func() {
+		foreign := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: fsName}, Provisioner: "example.com/other"}
+		setup(fs, foreign, discoveryResult("worker-0",
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdb", WWN: "uuid.6000c29a"},
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdc", WWN: "uuid.6000c29b"}))
+		// IBM Storage Scale keeps a deleted LocalDisk until its finalizer is removed
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if obj.GetObjectKind().GroupVersionKind() == utils.IBMLocalDiskGVK {
+					obj.SetFinalizers([]string{"scale.spectrum.ibm.com/localdisk"})
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).To(HaveOccurred())
+		Expect(r.Delete(ctx, foreign)).To(Succeed())
+
+		result, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(waitForComponents))
+		cond := condition(fusionv1alpha1.FileSystemConditionResourcesCreated)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha1.ReasonResourcesTerminating))
+		Expect(cond.Message).To(ContainSubstring("sdb-uuid-6000c29a"))
+
+		for _, name := range []string{"sdb-uuid-6000c29a", "sdc-uuid-6000c29b"} {
+			localDisk, err := getIBM(utils.IBMLocalDiskGVK, name)
+			Expect(err).ToNot(HaveOccurred())
+			localDisk.SetFinalizers(nil)
+			Expect(r.Update(ctx, localDisk)).To(Succeed())
+		}
+		_, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(condition(fusionv1alpha1.FileSystemConditionResourcesCreated).Status).To(Equal(metav1.ConditionTrue))
+		localDisk, err := getIBM(utils.IBMLocalDiskGVK, "sdb-uuid-6000c29a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(localDisk.GetDeletionTimestamp()).To(BeNil())
+	})
+
+	It("refuses the disks holding data until it is acknowledged", func() {
+		setup(fs, discoveryResult("worker-0",
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdb", WWN: "uuid.6000c29a", ExistingData: []string{"LVM2_member"}},
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdc", WWN: "uuid.6000c29b"}))
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		cond := condition(fusionv1alpha1.FileSystemConditionResourcesCreated)
+		Expect(cond.Reason).To(Equal(fusionv1alpha1.ReasonDisksContainData))
+		Expect(cond.Message).To(ContainSubstring("uuid.6000c29a (LVM2_member)"))
+		Expect(getFileSystem().Status.Disks[0].Phase).To(Equal(fusionv1alpha1.DiskDataFound))
+		Expect(getFileSystem().Status.Disks[1].Phase).To(Equal(fusionv1alpha1.DiskPending))
+		Expect(<-recorder.Events).To(ContainSubstring("Warning ExistingDataFound"))
+		_, err = getIBM(utils.IBMLocalDiskGVK, "sdc-uuid-6000c29b")
+		Expect(kerrors.IsNotFound(err)).To(BeTrue())
+
+		current := getFileSystem()
+		current.Spec.WipeExistingData = []string{"uuid.6000c29a"}
+		Expect(r.Update(ctx, current)).To(Succeed())
+		_, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		localDisk, err := getIBM(utils.IBMLocalDiskGVK, "sdb-uuid-6000c29a")
+		Expect(err).ToNot(HaveOccurred())
+		skipVerify, _, _ := unstructured.NestedBool(localDisk.Object, "spec", "existingDataSkipVerify")
+		Expect(skipVerify).To(BeTrue())
+		localDisk, err = getIBM(utils.IBMLocalDiskGVK, "sdc-uuid-6000c29b")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(localDisk.Object["spec"]).ToNot(HaveKey("existingDataSkipVerify"))
+		Expect(condition(fusionv1alpha1.FileSystemConditionResourcesCreated).Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("ignores the data Storage Scale writes on the disks it created", func() {
+		result := discoveryResult("worker-0",
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdb", WWN: "uuid.6000c29a"},
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdc", WWN: "uuid.6000c29b"})
+		setup(fs, result)
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		for i := range result.Status.DiscoveredDevices {
+			result.Status.DiscoveredDevices[i].ExistingData = []string{"gpfs_nsd"}
+		}
+		Expect(r.Update(ctx, result)).To(Succeed())
+		_, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(condition(fusionv1alpha1.FileSystemConditionResourcesCreated).Status).To(Equal(metav1.ConditionTrue))
+		Expect(getFileSystem().Status.Disks[0].ExistingData).To(BeEmpty())
+	})
+
+	Context("when disks are added", func() {
+		var result *fusionv1alpha1.LocalVolumeDiscoveryResult
+
+		// createFileSystem creates the filesystem from the first two disks and adds uuid.6000c29c to the spec
+		createFileSystem := func(objs ...client.Object) {
+			result = discoveryResult("worker-0",
+				fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdb", WWN: "uuid.6000c29a", Size: 1 << 30},
+				fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdc", WWN: "uuid.6000c29b", Size: 1 << 30},
+				fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdd", WWN: "uuid.6000c29c", Size: 2 << 30})
+			setup(append(objs, fs, result)...)
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(<-recorder.Events).To(ContainSubstring("Normal ResourcesCreated"))
+
+			current := getFileSystem()
+			current.Spec.Disks = append(current.Spec.Disks, "uuid.6000c29c")
+			Expect(r.Update(ctx, current)).To(Succeed())
+		}
+
+		poolDisks := func() []any {
+			filesystem, err := getIBM(utils.IBMFilesystemGVK, fsName)
+			Expect(err).ToNot(HaveOccurred())
+			pools, _, _ := unstructured.NestedSlice(filesystem.Object, "spec", "local", "pools")
+			disks, _, _ := unstructured.NestedSlice(pools[0].(map[string]any), "disks")
+			return disks
+		}
+
+		It("adds their LocalDisks to the Filesystem and reports the capacity", func() {
+			createFileSystem()
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ToNot(HaveOccurred())
+
+			localDisk, err := getIBM(utils.IBMLocalDiskGVK, "sdd-uuid-6000c29c")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(localDisk.Object["spec"]).To(HaveKeyWithValue("node", "worker-0"))
+			Expect(poolDisks()).To(Equal([]any{"sdb-uuid-6000c29a", "sdc-uuid-6000c29b", "sdd-uuid-6000c29c"}))
+			Expect(condition(fusionv1alpha1.FileSystemConditionDisksAdded).Status).To(Equal(metav1.ConditionTrue))
+			Expect(<-recorder.Events).To(Equal("Normal FileSystemExpanded Added the LocalDisks sdd-uuid-6000c29c to the filesystem"))
+
+			for _, name := range []string{"sdb-uuid-6000c29a", "sdd-uuid-6000c29c"} {
+				localDisk, err := getIBM(utils.IBMLocalDiskGVK, name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(unstructured.SetNestedField(localDisk.Object, fsName, "status", "filesystem")).To(Succeed())
+				Expect(r.Update(ctx, localDisk)).To(Succeed())
+			}
+			result, err := r.Reconcile(ctx, request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(waitForComponents))
+			Expect(getFileSystem().Status.Capacity.String()).To(Equal("3Gi"))
+			Expect(getFileSystem().Status.Disks[2].Phase).To(Equal(fusionv1alpha1.DiskInUse))
+		})
+
+		It("leaves out the disks that are not visible on all the storage nodes", func() {
+			createFileSystem()
+			Expect(r.Create(ctx, storageNode("worker-1"))).To(Succeed())
+			Expect(r.Create(ctx, discoveryResult("worker-1",
+				fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdb", WWN: "uuid.6000c29a"},
+				fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdc", WWN: "uuid.6000c29b"}))).To(Succeed())
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = getIBM(utils.IBMLocalDiskGVK, "sdd-uuid-6000c29c")
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+			Expect(poolDisks()).To(HaveLen(2))
+			cond := condition(fusionv1alpha1.FileSystemConditionDisksAdded)
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(fusionv1alpha1.ReasonDisksNotShared))
+			Expect(cond.Message).To(ContainSubstring("uuid.6000c29c (not on worker-1)"))
+			Expect(condition(fusionv1alpha1.FileSystemConditionResourcesCreated).Status).To(Equal(metav1.ConditionTrue))
+			Expect(getFileSystem().Status.Disks[2].Phase).To(Equal(fusionv1alpha1.DiskNotShared))
+			Expect(getFileSystem().Status.Disks[2].Message).To(Equal("Not visible on the storage nodes worker-1"))
+		})
+
+		It("adds the disks that can be used while leaving out the ones that cannot", func() {
+			createFileSystem()
+			result.Status.DiscoveredDevices = append(result.Status.DiscoveredDevices,
+				fusionv1alpha1.DiscoveredDevice{Path: "/dev/sde", WWN: "uuid.6000c29d", Size: 1 << 30, ExistingData: []string{"xfs"}})
+			Expect(r.Update(ctx, result)).To(Succeed())
+			current := getFileSystem()
+			current.Spec.Disks = append(current.Spec.Disks, "uuid.6000c29d")
+			Expect(r.Update(ctx, current)).To(Succeed())
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(poolDisks()).To(Equal([]any{"sdb-uuid-6000c29a", "sdc-uuid-6000c29b", "sdd-uuid-6000c29c"}))
+			_, err = getIBM(utils.IBMLocalDiskGVK, "sde-uuid-6000c29d")
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+			disks := getFileSystem().Status.Disks
+			Expect(disks[2].Phase).To(Equal(fusionv1alpha1.DiskCreated))
+			Expect(disks[3].Phase).To(Equal(fusionv1alpha1.DiskDataFound))
+			Expect(disks[3].Message).To(Equal("The disk holds existing data: xfs"))
+			cond := condition(fusionv1alpha1.FileSystemConditionDisksAdded)
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(fusionv1alpha1.ReasonDisksContainData))
+		})
+	})
+
+	It("only handles filesystems in the IBM namespace", func() {
+		fs.Namespace = "default"
+		setup(fs, discoveryResult("worker-0",
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdb", WWN: "uuid.6000c29a"},
+			fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdc", WWN: "uuid.6000c29b"},
+		))
+		key := types.NamespacedName{Name: fsName, Namespace: "default"}
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		current := &fusionv1alpha1.FusionAccessFileSystem{}
+		Expect(r.Get(ctx, key, current)).To(Succeed())
+		Expect(current.Finalizers).To(BeEmpty())
+		created := meta.FindStatusCondition(current.Status.Conditions, fusionv1alpha1.FileSystemConditionResourcesCreated)
+		Expect(created.Reason).To(Equal(fusionv1alpha1.ReasonUnsupportedNamespace))
+		_, err = getIBM(utils.IBMFilesystemGVK, fsName)
+		Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	Context("when deleted", func() {
+		deletedFileSystem := func() *fusionv1alpha1.FusionAccessFileSystem {
+			fs.Finalizers = []string{fileSystemFinalizer}
+			fs.DeletionTimestamp = ptr.To(metav1.Now())
+			fs.Status.Disks = []fusionv1alpha1.FileSystemDiskStatus{
+				{WWN: "uuid.6000c29a", Node: "worker-0", Device: "/dev/sdb", LocalDisk: "sdb-uuid-6000c29a"},
+				{WWN: "uuid.6000c29b", Node: "worker-0", Device: "/dev/sdc", LocalDisk: "sdc-uuid-6000c29b"},
+			}
+			return fs
+		}
+
+		It("waits for the persistent volumes of the StorageClass to be removed", func() {
+			volume := &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+				Spec:       corev1.PersistentVolumeSpec{StorageClassName: fsName},
+			}
+			storageClass := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: fsName}, Provisioner: fileSystemProvisioner}
+			Expect(kubeutils.SetOwner(deletedFileSystem(), storageClass, createFakeScheme())).To(Succeed())
+			setup(deletedFileSystem(), volume, storageClass)
+			result, err := r.Reconcile(ctx, request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(waitForComponents))
+			Expect(condition(fusionv1alpha1.FileSystemConditionReady).Reason).To(Equal(fusionv1alpha1.ReasonVolumesExist))
+			Expect(getFileSystem().Finalizers).To(ContainElement(fileSystemFinalizer))
+		})
+
+		It("ignores the volumes of a StorageClass of the same name that is not its own", func() {
+			volume := &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+				Spec:       corev1.PersistentVolumeSpec{StorageClassName: fsName},
+			}
+			storageClass := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: fsName}, Provisioner: fileSystemProvisioner}
+			setup(deletedFileSystem(), volume, storageClass)
+			for range 2 {
+				_, err := r.Reconcile(ctx, request)
+				Expect(err).ToNot(HaveOccurred())
+			}
+			err := r.Get(ctx, request.NamespacedName, &fusionv1alpha1.FusionAccessFileSystem{})
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+			Expect(r.Get(ctx, types.NamespacedName{Name: fsName}, &storagev1.StorageClass{})).To(Succeed())
+		})
+
+		It("removes the StorageClass and the Filesystem before the LocalDisks", func() {
+			setup(deletedFileSystem())
+			children, err := r.fileSystemResources(fs, fs.Status.Disks)
+			Expect(err).ToNot(HaveOccurred())
+			for _, child := range children {
+				Expect(r.Create(ctx, child)).To(Succeed())
+			}
+			// IBM Storage Scale refuses to delete a Filesystem without the allowDelete label
+			deletedLabels := map[string]map[string]string{}
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					deletedLabels[obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName()] = obj.GetLabels()
+					return c.Delete(ctx, obj, opts...)
+				},
+			})
+
+			result, err := r.Reconcile(ctx, request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(waitForComponents))
+			_, err = getIBM(utils.IBMFilesystemGVK, fsName)
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+			Expect(deletedLabels["Filesystem/"+fsName]).To(HaveKey(fileSystemAllowDeleteLabel))
+			_, err = getIBM(utils.IBMLocalDiskGVK, "sdb-uuid-6000c29a")
+			Expect(err).ToNot(HaveOccurred())
+
+			for range 2 {
+				_, err = r.Reconcile(ctx, request)
+				Expect(err).ToNot(HaveOccurred())
+			}
+			_, err = getIBM(utils.IBMLocalDiskGVK, "sdb-uuid-6000c29a")
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+			err = r.Get(ctx, request.NamespacedName, &fusionv1alpha1.FusionAccessFileSystem{})
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+			Expect(<-recorder.Events).To(Equal("Normal Deleting Removing the StorageClass, the Filesystem and the LocalDisks"))
+		})
+	})
+
+	It("enqueues the filesystems whose disks were not all added on discovery results", func() {
+		created := fs.DeepCopy()
+		created.Name = "created-fs"
+		created.Status.Conditions = []metav1.Condition{{
+			Type: fusionv1alpha1.FileSystemConditionDisksAdded, Status: metav1.ConditionTrue,
+			Reason: fusionv1alpha1.ReasonDisksAdded, LastTransitionTime: metav1.Now(),
+		}}
+		setup(fs, created)
+		requests := r.getPendingFileSystemRequests(ctx, discoveryResult("worker-0"))
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].NamespacedName).To(Equal(request.NamespacedName))
+	})
+})
@@ -0,0 +1,270 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+const (
+	// imageCheckImageAnnotation and imageCheckSecretAnnotation record what a check pod is verifying,
+	// so that a pod started for an older image or entitlement key is not mistaken for the current one
+	imageCheckImageAnnotation  = "fusion.storage.openshift.io/image"
+	imageCheckSecretAnnotation = "fusion.storage.openshift.io/secret-hash"
+)
+
+var (
+	// imageDigestResolver looks up the digest of an image reference in its registry
+	imageDigestResolver = resolveImageDigest
+	// imageDigestTimeout keeps an unreachable registry from holding up the reconcile
+	imageDigestTimeout = 10 * time.Second
+	// waitForImagePullCheck is how often we look at the check pod while the image is being pulled
+	waitForImagePullCheck = ctrl.Result{RequeueAfter: utils.CheckPodPullInterval}
+	// imagePullCheckRetryInterval is how long a failed check is trusted before it is run again,
+	// in case the registry was only temporarily unavailable
+	imagePullCheckRetryInterval = 10 * time.Minute
+)
+
+// reconcileImagePullCheck verifies that the IBM images can be pulled with the entitlement key without
+// blocking the reconcile: a check pod is started and its outcome is picked up on a later reconcile.
+// The result is kept in the status and only checked again when the digest the image resolves to or the
+// entitlement key change. The registry is asked for the digest once the result is older than the retry
+// interval, and when it cannot be asked, the image reference is used.
+// The returned result asks for a requeue while the check is running or until a failed check is retried
+func (r *FusionAccessReconciler) reconcileImagePullCheck(
+	ctx context.Context,
+	ns string,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+) (ctrl.Result, error) {
+	testImage, err := utils.GetExternalTestImage(string(fusionaccess.Spec.StorageScaleVersion))
+	if err != nil {
+		log.Log.Error(err, "Could not figure out test image", "testImage", testImage)
+		return ctrl.Result{}, err
+	}
+	// On a disconnected cluster the mirror has to be checked, not the registry it mirrors
+	mirrors, err := r.getImageMirrors(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if mirrored := mirrors.mirrorsFor(testImage); len(mirrored) > 0 {
+		testImage = mirrored[0]
+	}
+	secretHash, err := r.getEntitlementSecretHash(ctx, ns)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// A recent result is trusted without asking the registry, which may be slow or unreachable
+	check := fusionaccess.Status.ImagePullCheck
+	sameCheck := check != nil && check.Image == testImage && check.SecretHash == secretHash
+	if sameCheck && time.Since(check.LastCheckTime.Time) < imagePullCheckRetryInterval {
+		setImagePullCondition(fusionaccess, check)
+		return imagePullCheckResult(check), nil
+	}
+
+	pods := r.fullClient.CoreV1().Pods(ns)
+	pod, err := pods.Get(ctx, utils.CheckPodName, v1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	// The pod pulls exactly the digest the result is recorded for. A running check keeps the digest it
+	// was started with, the registry is only asked before a check starts
+	podImage, running := "", false
+	if err == nil {
+		podImage, running = checkPodImage(pod, testImage, secretHash)
+	}
+	if !running {
+		// A tag can be moved to another image, so the result is kept for the digest it resolves to
+		digest, err := r.getImageDigest(ctx, ns, testImage)
+		if err != nil {
+			log.Log.Info("Could not resolve the digest of the test image, its reference is checked instead", "testImage", testImage, "error", err.Error())
+		}
+		if sameCheck && check.Succeeded && (digest == "" || check.ImageID == digest) {
+			// The image did not change since it was pulled, the result is trusted for another interval
+			check.LastCheckTime = v1.Now()
+			setImagePullCondition(fusionaccess, check)
+			return imagePullCheckResult(check), nil
+		}
+		podImage = testImage
+		if digest != "" {
+			podImage = pinImage(testImage, digest)
+		}
+	}
+
+	if err == nil && !running {
+		log.Log.Info("Removing stale image check pod", "ns", ns, "pod", pod.Name)
+		if err := pods.Delete(ctx, pod.Name, v1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// The pod is recreated once the old one is gone
+		setCondition(fusionaccess, fusionv1alpha1.ConditionImagePull, v1.ConditionUnknown,
+			fusionv1alpha1.ReasonImagePullInProgress, fmt.Sprintf("Checking that %s can be pulled", testImage))
+		return waitForImagePullCheck, nil
+	}
+	if kerrors.IsNotFound(err) {
+		pod = utils.NewImageCheckPod(ns, podImage, IBMENTITLEMENTNAME)
+		pod.Annotations = map[string]string{
+			imageCheckImageAnnotation:  podImage,
+			imageCheckSecretAnnotation: secretHash,
+		}
+		log.Log.Info("Starting image pull check", "ns", ns, "testImage", testImage)
+		if _, err := pods.Create(ctx, pod, v1.CreateOptions{}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create image check pod: %w", err)
+		}
+		r.recorder.Eventf(fusionaccess, corev1.EventTypeNormal, EventImagePullCheckStarted, "Checking that %s can be pulled", testImage)
+		setCondition(fusionaccess, fusionv1alpha1.ConditionImagePull, v1.ConditionUnknown,
+			fusionv1alpha1.ReasonImagePullInProgress, fmt.Sprintf("Checking that %s can be pulled", testImage))
+		return waitForImagePullCheck, nil
+	}
+
+	done, pullErr := utils.GetPodPullStatus(pod)
+	if !done && time.Since(pod.CreationTimestamp.Time) < utils.CheckPodMaxImagePullTimeout {
+		setCondition(fusionaccess, fusionv1alpha1.ConditionImagePull, v1.ConditionUnknown,
+			fusionv1alpha1.ReasonImagePullInProgress, fmt.Sprintf("Checking that %s can be pulled", testImage))
+		return waitForImagePullCheck, nil
+	}
+	if !done {
+		pullErr = fmt.Errorf("image pull did not complete within %s", utils.CheckPodMaxImagePullTimeout)
+	}
+
+	digest := ""
+	if podImage != testImage {
+		_, digest, _ = strings.Cut(podImage, "@")
+	}
+	check = &fusionv1alpha1.ImagePullCheckStatus{
+		Image:         testImage,
+		ImageID:       digest,
+		SecretHash:    secretHash,
+		Succeeded:     pullErr == nil,
+		LastCheckTime: v1.Now(),
+	}
+	if pullErr != nil {
+		log.Log.Error(pullErr, "Image pull test failed", "ns", ns, "testImage", testImage)
+		check.Message = pullErr.Error()
+		r.recorder.Eventf(fusionaccess, corev1.EventTypeWarning, EventImagePullFailed, "%s can't be pulled: %v", testImage, pullErr)
+	} else {
+		log.Log.Info("Image pull test succeeded", "ns", ns, "testImage", testImage)
+		if check.ImageID == "" {
+			_, check.ImageID, _ = strings.Cut(pod.Status.ContainerStatuses[0].ImageID, "@")
+		}
+		r.recorder.Eventf(fusionaccess, corev1.EventTypeNormal, EventImagePullSucceeded, "%s was pulled successfully", testImage)
+	}
+	fusionaccess.Status.ImagePullCheck = check
+	setImagePullCondition(fusionaccess, check)
+
+	if err := pods.Delete(ctx, pod.Name, v1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	return imagePullCheckResult(check), nil
+}
+
+// imagePullCheckResult makes sure a failed check is run again once it is not trusted anymore
+func imagePullCheckResult(check *fusionv1alpha1.ImagePullCheckStatus) ctrl.Result {
+	if check.Succeeded {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: max(time.Until(check.LastCheckTime.Add(imagePullCheckRetryInterval)), time.Second)}
+}
+
+func setImagePullCondition(fusionaccess *fusionv1alpha1.FusionAccess, check *fusionv1alpha1.ImagePullCheckStatus) {
+	if check.Succeeded {
+		setCondition(fusionaccess, fusionv1alpha1.ConditionImagePull, v1.ConditionTrue,
+			fusionv1alpha1.ReasonImagePullDone, "protected images pulled successfully")
+		return
+	}
+	fusionaccess.Status.Status = "ErrImagePull"
+	setCondition(fusionaccess, fusionv1alpha1.ConditionImagePull, v1.ConditionFalse,
+		fusionv1alpha1.ReasonImagePullFailed, fmt.Sprintf("protected images can't be pulled: %s", check.Message))
+}
+
+// getImageDigest resolves the image with the entitlement key, or with the global pull secret for a registry
+// the entitlement key has no credentials for, such as a mirror
+func (r *FusionAccessReconciler) getImageDigest(ctx context.Context, ns, image string) (string, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return "", err
+	}
+	var dockerConfig []byte
+	secret, err := r.fullClient.CoreV1().Secrets(ns).Get(ctx, IBMENTITLEMENTNAME, v1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return "", err
+	}
+	if err == nil {
+		dockerConfig = secret.Data[corev1.DockerConfigJsonKey]
+	}
+	if username, _, _ := registryCredentials(dockerConfig, ref.Registry, ref.Repository); username == "" {
+		global := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: "openshift-config", Name: "pull-secret"}, global)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return "", err
+		}
+		dockerConfig = global.Data[corev1.DockerConfigJsonKey]
+	}
+	ctx, cancel := context.WithTimeout(ctx, imageDigestTimeout)
+	defer cancel()
+	return imageDigestResolver(ctx, image, dockerConfig, nil)
+}
+
+// checkPodImage returns the image of a check pod started for the test image and the entitlement key, and
+// false when there is no such pod
+func checkPodImage(pod *corev1.Pod, testImage, secretHash string) (string, bool) {
+	if pod.Annotations[imageCheckSecretAnnotation] != secretHash {
+		return "", false
+	}
+	image := pod.Annotations[imageCheckImageAnnotation]
+	if image != testImage && !strings.HasPrefix(image, pinImage(testImage, "")) {
+		return "", false
+	}
+	return image, true
+}
+
+// pinImage replaces the tag of an image reference with a digest
+func pinImage(image, digest string) string {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return image
+	}
+	return fmt.Sprintf("%s/%s@%s", ref.Registry, ref.Repository, digest)
+}
+
+// getEntitlementSecretHash returns a hash of the entitlement key used by the check pod, or of
+// nothing at all when it does not exist and only the global pull secret can be used
+func (r *FusionAccessReconciler) getEntitlementSecretHash(ctx context.Context, ns string) (string, error) {
+	h := sha256.New()
+	secret, err := r.fullClient.CoreV1().Secrets(ns).Get(ctx, IBMENTITLEMENTNAME, v1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return "", err
+	}
+	if err == nil {
+		h.Write(secret.Data[corev1.DockerConfigJsonKey])
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubeclient "k8s.io/client-go/kubernetes/fake"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+var _ = Describe("reconcileImagePullCheck", func() {
+	const ns = "ibm-fusion-access-operator"
+
+	var (
+		ctx          = context.Background()
+		fullClient   kubernetes.Interface
+		r            *FusionAccessReconciler
+		fusionaccess *fusionv1alpha.FusionAccess
+		testImage    string
+		digest       string
+	)
+
+	// setPodState makes the check pod look like the kubelet reported the given container state
+	setPodState := func(state corev1.ContainerState) {
+		pod, err := fullClient.CoreV1().Pods(ns).Get(ctx, utils.CheckPodName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		pod.CreationTimestamp = metav1.Now()
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:    "check",
+			State:   state,
+			ImageID: "quay.io/example/test@sha256:1234",
+		}}
+		_, err = fullClient.CoreV1().Pods(ns).UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		var err error
+		testImage, err = utils.GetExternalTestImage("v5.2.3.1.dev3")
+		Expect(err).ToNot(HaveOccurred())
+		fullClient = kubeclient.NewSimpleClientset(newSecret(IBMENTITLEMENTNAME, ns,
+			map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)}, corev1.SecretTypeDockerConfigJson, nil))
+		r = newFakeReconciler(nil)
+		r.fullClient = fullClient
+		fusionaccess = &fusionv1alpha.FusionAccess{
+			Spec: fusionv1alpha.FusionAccessSpec{StorageScaleVersion: "v5.2.3.1.dev3"},
+		}
+		digest = "sha256:1234"
+		resolver := imageDigestResolver
+		imageDigestResolver = func(context.Context, string, []byte, []byte) (string, error) {
+			return digest, nil
+		}
+		DeferCleanup(func() { imageDigestResolver = resolver })
+	})
+
+	It("starts a check pod without waiting for it", func() {
+		result, err := r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(waitForImagePullCheck))
+
+		pod, err := fullClient.CoreV1().Pods(ns).Get(ctx, utils.CheckPodName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Spec.Containers[0].Image).To(Equal(pinImage(testImage, digest)))
+		Expect(pod.Spec.Containers[0].Image).To(HaveSuffix("@sha256:1234"))
+		Expect(pod.Annotations).To(HaveKeyWithValue(imageCheckImageAnnotation, pod.Spec.Containers[0].Image))
+		Expect(pod.Annotations).To(HaveKey(imageCheckSecretAnnotation))
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionImagePull)
+		Expect(cond).ToNot(BeNil())
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonImagePullInProgress))
+	})
+
+	It("records a successful pull and removes the check pod", func() {
+		_, err := r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		setPodState(corev1.ContainerState{Running: &corev1.ContainerStateRunning{}})
+
+		result, err := r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+
+		check := fusionaccess.Status.ImagePullCheck
+		Expect(check).ToNot(BeNil())
+		Expect(check.Succeeded).To(BeTrue())
+		Expect(check.Image).To(Equal(testImage))
+		Expect(check.ImageID).To(Equal(digest))
+		Expect(meta.IsStatusConditionTrue(fusionaccess.Status.Conditions, fusionv1alpha.ConditionImagePull)).To(BeTrue())
+		_, err = fullClient.CoreV1().Pods(ns).Get(ctx, utils.CheckPodName, metav1.GetOptions{})
+		Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("records a failed pull and retries it later", func() {
+		_, err := r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		setPodState(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+			Reason:  "ErrImagePull",
+			Message: "unauthorized",
+		}})
+
+		result, err := r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", time.Minute))
+		Expect(fusionaccess.Status.ImagePullCheck.Succeeded).To(BeFalse())
+		Expect(fusionaccess.Status.ImagePullCheck.Message).To(ContainSubstring("unauthorized"))
+		Expect(fusionaccess.Status.Status).To(Equal("ErrImagePull"))
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionImagePull)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonImagePullFailed))
+	})
+
+	It("does not run the check again when nothing changed", func() {
+		secretHash, err := r.getEntitlementSecretHash(ctx, ns)
+		Expect(err).ToNot(HaveOccurred())
+		fusionaccess.Status.ImagePullCheck = &fusionv1alpha.ImagePullCheckStatus{
+			Image:         testImage,
+			ImageID:       digest,
+			SecretHash:    secretHash,
+			Succeeded:     true,
+			LastCheckTime: metav1.NewTime(time.Now().Add(-24 * time.Hour)),
+		}
+
+		result, err := r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+		_, err = fullClient.CoreV1().Pods(ns).Get(ctx, utils.CheckPodName, metav1.GetOptions{})
+		Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("does not ask the registry while the result is recent or a check is running", func() {
+		resolved := 0
+		imageDigestResolver = func(context.Context, string, []byte, []byte) (string, error) {
+			resolved++
+			return digest, nil
+		}
+		_, err := r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		setPodState(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}})
+		result, err := r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(waitForImagePullCheck))
+		setPodState(corev1.ContainerState{Running: &corev1.ContainerStateRunning{}})
+		_, err = r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fusionaccess.Status.ImagePullCheck.ImageID).To(Equal(digest))
+
+		_, err = r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resolved).To(Equal(1))
+
+		// Once the result is old, the digest is looked up again and the result is kept while it is unchanged
+		fusionaccess.Status.ImagePullCheck.LastCheckTime = metav1.NewTime(time.Now().Add(-imagePullCheckRetryInterval))
+		result, err = r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+		Expect(resolved).To(Equal(2))
+		Expect(fusionaccess.Status.ImagePullCheck.LastCheckTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+	})
+
+	It("runs the check again when the tag resolves to another digest", func() {
+		secretHash, err := r.getEntitlementSecretHash(ctx, ns)
+		Expect(err).ToNot(HaveOccurred())
+		fusionaccess.Status.ImagePullCheck = &fusionv1alpha.ImagePullCheckStatus{
+			Image:         testImage,
+			ImageID:       "sha256:retagged",
+			SecretHash:    secretHash,
+			Succeeded:     true,
+			LastCheckTime: metav1.NewTime(time.Now().Add(-imagePullCheckRetryInterval)),
+		}
+
+		result, err := r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(waitForImagePullCheck))
+		pod, err := fullClient.CoreV1().Pods(ns).Get(ctx, utils.CheckPodName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Spec.Containers[0].Image).To(HaveSuffix("@" + digest))
+	})
+
+	It("runs the check again when the entitlement key changes", func() {
+		fusionaccess.Status.ImagePullCheck = &fusionv1alpha.ImagePullCheckStatus{
+			Image:         testImage,
+			SecretHash:    "old-key",
+			Succeeded:     true,
+			LastCheckTime: metav1.Now(),
+		}
+
+		result, err := r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(waitForImagePullCheck))
+		_, err = fullClient.CoreV1().Pods(ns).Get(ctx, utils.CheckPodName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("checks the mirror of the image on a disconnected cluster", func() {
+		Expect(r.Create(ctx, &configv1.ImageTagMirrorSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "storage-scale"},
+			Spec: configv1.ImageTagMirrorSetSpec{ImageTagMirrors: []configv1.ImageTagMirrors{{
+				Source:  "quay.io/openshift-storage-scale",
+				Mirrors: []configv1.ImageMirror{"mirror.example.com:5000/storage-scale"},
+			}}},
+		})).To(Succeed())
+
+		_, err := r.reconcileImagePullCheck(ctx, ns, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		pod, err := fullClient.CoreV1().Pods(ns).Get(ctx, utils.CheckPodName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		mirrored := strings.Replace(testImage, "quay.io/openshift-storage-scale", "mirror.example.com:5000/storage-scale", 1)
+		Expect(pod.Spec.Containers[0].Image).To(Equal(pinImage(mirrored, digest)))
+	})
+})
@@ -19,6 +19,7 @@ package kernelmodule
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
@@ -26,6 +27,7 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	configv1 "github.com/openshift/api/config/v1"
 	kmmv1beta1 "github.com/rh-ecosystem-edge/kernel-module-management/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,31 +45,65 @@ const (
 	IBMENTITLEMENTNAME = "ibm-entitlement-key"
 	SecureBootKey      = "secureboot-signing-key"
 	SecureBootKeyPub   = "secureboot-signing-key-pub"
+	// BaseImage is the image the built kernel module is shipped in
+	BaseImage = "registry.redhat.io/ubi9/ubi-minimal"
+	// mergedRegistriesAnnotation lists the registries the operator added to the global pull secret. Only
+	// those are removed again, the ones the global pull secret held before are left alone
+	mergedRegistriesAnnotation = "fusion.storage.openshift.io/merged-registries"
 )
 
 // CreateOrUpdateKMMResources creates or updates the resources needed for the kernel module builds
 // HEADS UP: consider cleanup of old resources in case of name changes or removals!
-func CreateOrUpdateKMMResources(ctx context.Context, cl client.Client) error {
+func CreateOrUpdateKMMResources(ctx context.Context, cl client.Client, owner client.Object) error {
+	if err := UpdateGlobalPullSecret(ctx, cl); err != nil {
+		return err
+	}
+	return CreateOrUpdateKernelModule(ctx, cl, owner)
+}
+
+// UpdateGlobalPullSecret adds the IBM registry credentials to the global pull secret so that KMM can
+// pull the IBM images to build the kernel module
+func UpdateGlobalPullSecret(ctx context.Context, cl client.Client) error {
 	ns, err := utils.GetDeploymentNamespace()
 	if err != nil {
-		return fmt.Errorf("failed to get namespace in CreateOrUpdateKMMResources: %w", err)
+		return fmt.Errorf("failed to get namespace in UpdateGlobalPullSecret: %w", err)
 	}
 
 	// This can all be dropped with KMM 2.4
 	var secret *corev1.Secret
 	if secret, err = getPatchedGlobalPullSecret(ctx, cl, ns); err != nil {
-		return fmt.Errorf("failed to getPatchedGlobalPullSecret in CreateOrUpdateKMMResources: %w", err)
+		return fmt.Errorf("failed to getPatchedGlobalPullSecret in UpdateGlobalPullSecret: %w", err)
 	}
 	if err := kubeutils.CreateOrUpdateResource(ctx, cl, secret, func(existing, desired *corev1.Secret) error {
 		existing.Type = desired.Type
 		existing.Data = desired.Data
+		if added, ok := desired.Annotations[mergedRegistriesAnnotation]; ok {
+			if existing.Annotations == nil {
+				existing.Annotations = map[string]string{}
+			}
+			existing.Annotations[mergedRegistriesAnnotation] = added
+		}
 		return nil
 	}); err != nil {
-		return fmt.Errorf("failed to update global pull secret in CreateOrUpdateKMMResources: %w", err)
+		return fmt.Errorf("failed to update global pull secret in UpdateGlobalPullSecret: %w", err)
+	}
+	return nil
+}
+
+// CreateOrUpdateKernelModule creates or updates the KMM Module building the kernel module from the IBM core image.
+// The Module and its Dockerfile are owned by owner
+func CreateOrUpdateKernelModule(ctx context.Context, cl client.Client, owner client.Object) error {
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return fmt.Errorf("failed to get namespace in CreateOrUpdateKernelModule: %w", err)
 	}
 
 	dockerConfigmap := NewDockerConfigmap(ns)
+	if err := kubeutils.SetOwner(owner, dockerConfigmap, cl.Scheme()); err != nil {
+		return fmt.Errorf("failed to set the owner of the dockerconfigmap for KMM: %w", err)
+	}
 	if err := kubeutils.CreateOrUpdateResource(ctx, cl, dockerConfigmap, func(existing, desired *corev1.ConfigMap) error {
+		kubeutils.CopyOwner(existing, desired)
 		existing.Data = desired.Data
 		return nil
 	}); err != nil {
@@ -76,12 +112,65 @@ func CreateOrUpdateKMMResources(ctx context.Context, cl client.Client) error {
 
 	ibmScaleImage, err := getIBMCoreImage(ctx, cl)
 	if err != nil {
-		return fmt.Errorf("failed to get coreImage in CreateOrUpdateKMMResources: %w", err)
+		return fmt.Errorf("failed to get coreImage in CreateOrUpdateKernelModule: %w", err)
 	}
 	signModules := doSigningSecretsExist(ctx, cl, ns)
 	kernelModule := NewKMMModule(ns, ibmScaleImage, signModules)
+	proxy, err := utils.GetClusterProxy(ctx, cl)
+	if err != nil {
+		return fmt.Errorf("failed to get the cluster proxy in CreateOrUpdateKernelModule: %w", err)
+	}
+	caBundleSecret := ""
+	if proxy != nil {
+		if caBundleSecret, err = createOrUpdateTrustedCABundle(ctx, cl, ns, owner); err != nil {
+			return fmt.Errorf("failed to create the trusted CA bundle in CreateOrUpdateKernelModule: %w", err)
+		}
+	}
+	addProxyBuildArgs(kernelModule, proxy, caBundleSecret)
+	if err := kubeutils.SetOwner(owner, kernelModule, cl.Scheme()); err != nil {
+		return fmt.Errorf("failed to set the owner of the kernelModule in CreateOrUpdateKernelModule: %w", err)
+	}
 	if err := kubeutils.CreateOrUpdateResource(ctx, cl, kernelModule, mutateKMMModule); err != nil {
-		return fmt.Errorf("failed to update kernelModule in CreateOrUpdateKMMResources: %w", err)
+		return fmt.Errorf("failed to update kernelModule in CreateOrUpdateKernelModule: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteKMMResources removes the resources created by CreateOrUpdateKMMResources and drops the IBM
+// registry credentials the operator added from the global pull secret again
+func DeleteKMMResources(ctx context.Context, cl client.Client) error {
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return fmt.Errorf("failed to get namespace in DeleteKMMResources: %w", err)
+	}
+
+	kernelModule := &kmmv1beta1.Module{ObjectMeta: metav1.ObjectMeta{Name: KMMModuleName, Namespace: ns}}
+	if err := cl.Delete(ctx, kernelModule); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete kernelModule in DeleteKMMResources: %w", err)
+	}
+
+	if err := cl.Delete(ctx, NewDockerConfigmap(ns)); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete dockerconfigmap in DeleteKMMResources: %w", err)
+	}
+
+	caBundle := metav1.ObjectMeta{Name: utils.TrustedCABundleConfigMap, Namespace: ns}
+	if err := cl.Delete(ctx, &corev1.ConfigMap{ObjectMeta: caBundle}); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete the trusted CA bundle in DeleteKMMResources: %w", err)
+	}
+	if err := cl.Delete(ctx, &corev1.Secret{ObjectMeta: caBundle}); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete the trusted CA bundle secret in DeleteKMMResources: %w", err)
+	}
+
+	secret, err := getRestoredGlobalPullSecret(ctx, cl)
+	if err != nil {
+		return fmt.Errorf("failed to getRestoredGlobalPullSecret in DeleteKMMResources: %w", err)
+	}
+	if secret == nil {
+		return nil
+	}
+	if err := cl.Update(ctx, secret); err != nil {
+		return fmt.Errorf("failed to restore global pull secret in DeleteKMMResources: %w", err)
 	}
 
 	return nil
@@ -99,10 +188,83 @@ func doSigningSecretsExist(ctx context.Context, cl client.Client, namespace stri
 }
 
 func mutateKMMModule(existing, desired *kmmv1beta1.Module) error {
+	kubeutils.CopyOwner(existing, desired)
 	existing.Spec = desired.Spec
 	return nil
 }
 
+// createOrUpdateTrustedCABundle lets OpenShift inject the trusted CA bundle of the cluster into a ConfigMap
+// in the namespace and copies it into a Secret of the same name, as the build only mounts Secrets. It
+// returns the name of the Secret, which is empty until the bundle has been injected. Both are owned by
+// owner, so that an injected or rotated bundle triggers its reconciler
+func createOrUpdateTrustedCABundle(ctx context.Context, cl client.Client, ns string, owner client.Object) (string, error) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.TrustedCABundleConfigMap,
+			Namespace: ns,
+			Labels:    map[string]string{utils.InjectTrustedCABundleLabel: "true"},
+		},
+	}
+	if err := kubeutils.SetOwner(owner, cm, cl.Scheme()); err != nil {
+		return "", err
+	}
+	// The data is left to OpenShift
+	if err := kubeutils.CreateOrUpdateResource(ctx, cl, cm, func(existing, desired *corev1.ConfigMap) error {
+		kubeutils.CopyOwner(existing, desired)
+		existing.Labels[utils.InjectTrustedCABundleLabel] = "true"
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	bundle, err := utils.GetTrustedCABundle(ctx, cl, ns)
+	if err != nil || bundle == "" {
+		return "", err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: utils.TrustedCABundleConfigMap, Namespace: ns},
+		Data:       map[string][]byte{utils.TrustedCABundleKey: []byte(bundle)},
+	}
+	if err := kubeutils.SetOwner(owner, secret, cl.Scheme()); err != nil {
+		return "", err
+	}
+	if err := kubeutils.CreateOrUpdateResource(ctx, cl, secret, func(existing, desired *corev1.Secret) error {
+		kubeutils.CopyOwner(existing, desired)
+		existing.Data = desired.Data
+		return nil
+	}); err != nil {
+		return "", err
+	}
+	return secret.Name, nil
+}
+
+// addProxyBuildArgs passes the cluster proxy to the kernel module build, so that the build can reach the
+// registries and package repositories through it. The trusted CA bundle, which holds the CA of the proxy,
+// is mounted into the build from caBundleSecret unless it is empty
+func addProxyBuildArgs(kernelModule *kmmv1beta1.Module, proxy *configv1.ProxyStatus, caBundleSecret string) {
+	if proxy == nil {
+		return
+	}
+	for i := range kernelModule.Spec.ModuleLoader.Container.KernelMappings {
+		build := kernelModule.Spec.ModuleLoader.Container.KernelMappings[i].Build
+		if build == nil {
+			continue
+		}
+		if caBundleSecret != "" {
+			build.Secrets = append(build.Secrets, corev1.LocalObjectReference{Name: caBundleSecret})
+		}
+		for _, arg := range []kmmv1beta1.BuildArg{
+			{Name: "HTTP_PROXY", Value: proxy.HTTPProxy},
+			{Name: "HTTPS_PROXY", Value: proxy.HTTPSProxy},
+			{Name: "NO_PROXY", Value: proxy.NoProxy},
+		} {
+			if arg.Value != "" {
+				build.BuildArgs = append(build.BuildArgs, arg)
+			}
+		}
+	}
+}
+
 func NewKMMModule(namespace, ibmScaleImage string, sign bool) *kmmv1beta1.Module {
 	var signing *kmmv1beta1.Sign
 	var selector map[string]string
@@ -188,6 +350,21 @@ func getPatchedGlobalPullSecret(ctx context.Context, cl client.Client, namespace
 		return nil, fmt.Errorf("failed to get global pull secret in getPatchedGlobalPullSecret: %w", err)
 	}
 
+	added, err := addedRegistries(globalPullSecret, ibmPullSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the registries of the pull secrets: %w", err)
+	}
+	// The auths the global pull secret held before are the admin's and are never overwritten,
+	// otherwise removing the added registries on uninstall would lose them
+	present, err := utils.DockerConfigRegistries(globalPullSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the registries of the global pull secret: %w", err)
+	}
+	owned := slices.DeleteFunc(present, func(reg string) bool { return slices.Contains(added, reg) })
+	ibmPullSecret, err = utils.UnmergeSecrets(ibmPullSecret, owned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to skip the registries of the global pull secret: %w", err)
+	}
 	mergedSecret, err := utils.MergeSecrets(globalPullSecret, ibmPullSecret)
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge global pull secret and ibm pull secret: %w", err)
@@ -197,9 +374,64 @@ func getPatchedGlobalPullSecret(ctx context.Context, cl client.Client, namespace
 		Name:      "pull-secret",
 		Namespace: "openshift-config",
 	}
+	if len(added) > 0 {
+		mergedSecret.Annotations = map[string]string{mergedRegistriesAnnotation: strings.Join(added, ",")}
+	}
 	return mergedSecret, nil
 }
 
+// addedRegistries returns the registries of the ibm pull secret the global pull secret did not hold before
+// the operator merged them, including the ones recorded by an earlier merge
+func addedRegistries(globalPullSecret, ibmPullSecret *corev1.Secret) ([]string, error) {
+	present, err := utils.DockerConfigRegistries(globalPullSecret)
+	if err != nil {
+		return nil, err
+	}
+	ibmRegistries, err := utils.DockerConfigRegistries(ibmPullSecret)
+	if err != nil {
+		return nil, err
+	}
+	added := mergedRegistries(globalPullSecret)
+	for _, reg := range ibmRegistries {
+		if !slices.Contains(present, reg) && !slices.Contains(added, reg) {
+			added = append(added, reg)
+		}
+	}
+	slices.Sort(added)
+	return added, nil
+}
+
+// mergedRegistries returns the registries recorded in the mergedRegistriesAnnotation of the secret
+func mergedRegistries(secret *corev1.Secret) []string {
+	registries := []string{}
+	for _, reg := range strings.Split(secret.Annotations[mergedRegistriesAnnotation], ",") {
+		if reg != "" {
+			registries = append(registries, reg)
+		}
+	}
+	return registries
+}
+
+// getRestoredGlobalPullSecret returns the global pull secret without the registries the operator added to
+// it, or nil if there is nothing left to remove
+func getRestoredGlobalPullSecret(ctx context.Context, cl client.Client) (*corev1.Secret, error) {
+	globalPullSecret := &corev1.Secret{}
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: "openshift-config", Name: "pull-secret"}, globalPullSecret); err != nil {
+		return nil, fmt.Errorf("failed to get global pull secret in getRestoredGlobalPullSecret: %w", err)
+	}
+	added := mergedRegistries(globalPullSecret)
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	restored, err := utils.UnmergeSecrets(globalPullSecret, added)
+	if err != nil {
+		return nil, err
+	}
+	delete(restored.Annotations, mergedRegistriesAnnotation)
+	return restored, nil
+}
+
 // getIBMCoreImage gets the core init image with the source code in them
 func getIBMCoreImage(ctx context.Context, cl client.Client) (string, error) {
 	cm := &corev1.ConfigMap{}
@@ -246,7 +478,7 @@ RUN /usr/lpp/mmfs/bin/mmbuildgpl
 RUN mkdir -p /opt/lib/modules/${KERNEL_FULL_VERSION}/
 RUN cp -avf /lib/modules/${KERNEL_FULL_VERSION}/extra/*.ko /opt/lib/modules/${KERNEL_FULL_VERSION}/
 RUN depmod -b /opt
-FROM registry.redhat.io/ubi9/ubi-minimal
+FROM ` + BaseImage + `
 ARG KERNEL_FULL_VERSION
 RUN mkdir -p /opt/lib/modules/${KERNEL_FULL_VERSION}/
 COPY --from=builder /opt/lib/modules/${KERNEL_FULL_VERSION}/*.ko /opt/lib/modules/${KERNEL_FULL_VERSION}/
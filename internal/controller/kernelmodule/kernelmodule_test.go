@@ -1,11 +1,21 @@
 package kernelmodule
 
 import (
+	"context"
 	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	kmmv1beta1 "github.com/rh-ecosystem-edge/kernel-module-management/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
 )
 
 var _ = Describe("ExtractImageVersion", func() {
@@ -75,6 +85,121 @@ var _ = Describe("getIBMCoreImageHashForLabel", func() {
 	})
 })
 
+var _ = Describe("addProxyBuildArgs", func() {
+	It("passes the cluster proxy to the build", func() {
+		module := NewKMMModule("ns", "quay.io/example/core-init:1.0", false)
+		addProxyBuildArgs(module, &configv1.ProxyStatus{HTTPProxy: "http://proxy:3128", NoProxy: ".cluster.local"}, "")
+		Expect(module.Spec.ModuleLoader.Container.KernelMappings[0].Build.BuildArgs).To(Equal([]kmmv1beta1.BuildArg{
+			{Name: "IBM_SCALE", Value: "quay.io/example/core-init:1.0"},
+			{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+			{Name: "NO_PROXY", Value: ".cluster.local"},
+		}))
+		Expect(module.Spec.ModuleLoader.Container.KernelMappings[0].Build.Secrets).To(BeEmpty())
+	})
+
+	It("mounts the trusted CA bundle into the build", func() {
+		module := NewKMMModule("ns", "quay.io/example/core-init:1.0", false)
+		addProxyBuildArgs(module, &configv1.ProxyStatus{HTTPProxy: "http://proxy:3128"}, utils.TrustedCABundleConfigMap)
+		Expect(module.Spec.ModuleLoader.Container.KernelMappings[0].Build.Secrets).To(Equal([]corev1.LocalObjectReference{
+			{Name: utils.TrustedCABundleConfigMap},
+		}))
+	})
+
+	It("adds nothing without a proxy", func() {
+		module := NewKMMModule("ns", "quay.io/example/core-init:1.0", false)
+		addProxyBuildArgs(module, nil, utils.TrustedCABundleConfigMap)
+		Expect(module.Spec.ModuleLoader.Container.KernelMappings[0].Build.BuildArgs).To(HaveLen(1))
+		Expect(module.Spec.ModuleLoader.Container.KernelMappings[0].Build.Secrets).To(BeEmpty())
+	})
+})
+
+var _ = Describe("createOrUpdateTrustedCABundle", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "ns", Name: utils.TrustedCABundleConfigMap}
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "ns", UID: "owner-uid"}}
+
+	It("copies the injected bundle into a Secret for the build", func() {
+		cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		name, err := createOrUpdateTrustedCABundle(ctx, cl, "ns", owner)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(name).To(BeEmpty())
+		cm := &corev1.ConfigMap{}
+		Expect(cl.Get(ctx, key, cm)).To(Succeed())
+		Expect(cm.Labels).To(HaveKeyWithValue(utils.InjectTrustedCABundleLabel, "true"))
+
+		// OpenShift injects the bundle
+		cm.Data = map[string]string{utils.TrustedCABundleKey: "injected CA"}
+		Expect(cl.Update(ctx, cm)).To(Succeed())
+		name, err = createOrUpdateTrustedCABundle(ctx, cl, "ns", owner)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(name).To(Equal(utils.TrustedCABundleConfigMap))
+		Expect(cl.Get(ctx, key, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKeyWithValue(utils.TrustedCABundleKey, "injected CA"))
+		secret := &corev1.Secret{}
+		Expect(cl.Get(ctx, key, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKeyWithValue(utils.TrustedCABundleKey, []byte("injected CA")))
+	})
+})
+
+var _ = Describe("global pull secret", func() {
+	ctx := context.Background()
+	globalKey := types.NamespacedName{Namespace: "openshift-config", Name: "pull-secret"}
+
+	newPullSecret := func(namespace, name, auths string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{` + auths + `}}`)},
+		}
+	}
+	roundTrip := func(globalAuths string) *corev1.Secret {
+		GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", "ns")
+		cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			newPullSecret("openshift-config", "pull-secret", globalAuths),
+			newPullSecret("ns", IBMENTITLEMENTNAME, `"cp.icr.io":{"auth":"ibm"}`),
+		).Build()
+		Expect(UpdateGlobalPullSecret(ctx, cl)).To(Succeed())
+		// A second merge finds the registry present and keeps it recorded
+		Expect(UpdateGlobalPullSecret(ctx, cl)).To(Succeed())
+		secret := &corev1.Secret{}
+		Expect(cl.Get(ctx, globalKey, secret)).To(Succeed())
+		Expect(utils.DockerConfigRegistries(secret)).To(ContainElement("cp.icr.io"))
+
+		restored, err := getRestoredGlobalPullSecret(ctx, cl)
+		Expect(err).ToNot(HaveOccurred())
+		if restored != nil {
+			Expect(cl.Update(ctx, restored)).To(Succeed())
+		}
+		Expect(cl.Get(ctx, globalKey, secret)).To(Succeed())
+		Expect(secret.Annotations).ToNot(HaveKey(mergedRegistriesAnnotation))
+		return secret
+	}
+
+	It("removes the registries it added on uninstall", func() {
+		secret := roundTrip(`"quay.io":{"auth":"a"}`)
+		Expect(utils.DockerConfigRegistries(secret)).To(Equal([]string{"quay.io"}))
+	})
+
+	It("keeps the registries the global pull secret held before", func() {
+		secret := roundTrip(`"quay.io":{"auth":"a"},"cp.icr.io":{"auth":"own"}`)
+		Expect(utils.DockerConfigRegistries(secret)).To(Equal([]string{"cp.icr.io", "quay.io"}))
+		Expect(secret.Data[corev1.DockerConfigJsonKey]).To(MatchJSON(`{"auths":{"quay.io":{"auth":"a"},"cp.icr.io":{"auth":"own"}}}`))
+	})
+
+	It("does not overwrite the auths the global pull secret held before", func() {
+		GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", "ns")
+		cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			newPullSecret("openshift-config", "pull-secret", `"cp.icr.io":{"auth":"own"}`),
+			newPullSecret("ns", IBMENTITLEMENTNAME, `"cp.icr.io":{"auth":"ibm"},"icr.io":{"auth":"ibm"}`),
+		).Build()
+		Expect(UpdateGlobalPullSecret(ctx, cl)).To(Succeed())
+		secret := &corev1.Secret{}
+		Expect(cl.Get(ctx, globalKey, secret)).To(Succeed())
+		Expect(secret.Data[corev1.DockerConfigJsonKey]).To(MatchJSON(`{"auths":{"cp.icr.io":{"auth":"own"},"icr.io":{"auth":"ibm"}}}`))
+		Expect(secret.Annotations).To(HaveKeyWithValue(mergedRegistriesAnnotation, "icr.io"))
+	})
+})
+
 func TestGetIBMCoreImageHash(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "getIBMCoreImageHash Suite")
@@ -2,13 +2,14 @@ package localvolumediscovery
 
 import (
 	"context"
-	"os"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
 )
 
 // daemonsets are defined as: daemonSetMutateFn func(*appsv1.DaemonSet) error
@@ -93,10 +94,7 @@ func MutateAggregatedSpec(
 	ds.Spec.Template.Spec.ServiceAccountName = dsTemplate.Spec.Template.Spec.ServiceAccountName
 
 	// priority class
-
-	if os.Getenv("PRIORITY_CLASS_NAME") != "" {
-		ds.Spec.Template.Spec.PriorityClassName = os.Getenv("PRIORITY_CLASS_NAME")
-	}
+	ds.Spec.Template.Spec.PriorityClassName = common.GetPriorityClassName()
 
 	// tolerations
 	ds.Spec.Template.Spec.Tolerations = tolerations
@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 
 	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -29,7 +30,7 @@ func CreateOrUpdateLocalVolumeDiscovery(ctx context.Context, devicefinder *fusio
 	} else if err != nil {
 		return errors.Wrap(err, "could not check for existing device finder")
 	} else {
-		oldCP.OwnerReferences = devicefinder.OwnerReferences
+		kubeutils.CopyOwner(oldCP, devicefinder)
 		oldCP.Spec = devicefinder.Spec
 		if err := cl.Update(ctx, oldCP); err != nil {
 			return errors.Wrap(err, "could not update device finder")
@@ -37,3 +38,10 @@ func CreateOrUpdateLocalVolumeDiscovery(ctx context.Context, devicefinder *fusio
 	}
 	return nil
 }
+
+func DeleteLocalVolumeDiscovery(ctx context.Context, devicefinder *fusionv1alpha.LocalVolumeDiscovery, cl client.Client) error {
+	if err := cl.Delete(ctx, devicefinder); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "could not delete device finder")
+	}
+	return nil
+}
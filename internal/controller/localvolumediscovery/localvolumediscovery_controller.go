@@ -24,6 +24,7 @@ import (
 	localv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/assets"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/library-go/pkg/operator/resource/resourceread"
 	appsv1 "k8s.io/api/apps/v1"
@@ -35,9 +36,11 @@ import (
 	v1helper "k8s.io/component-helpers/scheduling/corev1"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -47,14 +50,29 @@ var (
 
 const (
 	DeviceFinderDiscovery = "devicefinder-discovery"
+
+	// Reasons of the events recorded on the LocalVolumeDiscovery object
+	DiscoveryDaemonsReady       = "DiscoveryDaemonsReady"
+	DiscoveryDaemonsProgressing = "DiscoveryDaemonsProgressing"
+	DiscoveryDegraded           = "DiscoveryDegraded"
 )
 
+// discoveryEvents are the type and reason of the event recorded when the discovery status changes, by
+// condition type
+var discoveryEvents = map[string]struct{ eventType, reason string }{
+	operatorv1.OperatorStatusTypeAvailable:   {corev1.EventTypeNormal, DiscoveryDaemonsReady},
+	operatorv1.OperatorStatusTypeProgressing: {corev1.EventTypeNormal, DiscoveryDaemonsProgressing},
+	operatorv1.OperatorStatusTypeDegraded:    {corev1.EventTypeWarning, DiscoveryDegraded},
+}
+
 // LocalVolumeDiscoveryReconciler reconciles a LocalVolumeDiscovery object
 type LocalVolumeDiscoveryReconciler struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
 	Client client.Client
 	Scheme *runtime.Scheme
+
+	recorder *kubeutils.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=localvolumediscoveries,verbs=get;list;watch;create;update;patch;delete
@@ -84,6 +102,21 @@ func (r *LocalVolumeDiscoveryReconciler) Reconcile(ctx context.Context, request
 		return ctrl.Result{}, err
 	}
 
+	if err := common.LoadOperatorConfig(ctx, r.Client); err != nil {
+		klog.ErrorS(err, "failed to read the operator configuration")
+		return ctrl.Result{}, err
+	}
+
+	paused, err := r.isPaused(ctx, instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		// Only the status is refreshed, the daemonset and the discovery results are left as they are
+		klog.InfoS("reconciliation is paused, skipping the discovery daemonset update", "annotation", localv1alpha1.PausedAnnotation)
+		return r.reportDaemonSetStatus(ctx, instance)
+	}
+
 	diskMakerDSMutateFn := getDeviceFinderDiscoveryDSMutateFn(request, instance.Spec.Tolerations,
 		getEnvVars(instance.Name, string(instance.UID)),
 		getOwnerRefs(instance),
@@ -100,6 +133,24 @@ func (r *LocalVolumeDiscoveryReconciler) Reconcile(ctx context.Context, request
 		klog.InfoS("daemonset changed", "daemonset.Name", ds.GetName(), "op.Result", opResult)
 	}
 
+	result, err := r.reportDaemonSetStatus(ctx, instance)
+	if err != nil || result.RequeueAfter > 0 {
+		return result, err
+	}
+
+	klog.Info("deleting orphan discovery result instances")
+	err = r.deleteOrphanDiscoveryResults(ctx, instance)
+	if err != nil {
+		klog.ErrorS(err, "failed to delete orphan discovery results")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reportDaemonSetStatus updates the discovery status from the discovery daemonset and requeues
+// until all its daemons are ready
+func (r *LocalVolumeDiscoveryReconciler) reportDaemonSetStatus(ctx context.Context, instance *localv1alpha1.LocalVolumeDiscovery) (ctrl.Result, error) {
 	desiredDaemons, readyDaemons, err := r.getDaemonSetStatus(ctx, instance.Namespace)
 	if err != nil {
 		klog.ErrorS(err, "failed to get discovery daemonset")
@@ -131,15 +182,49 @@ func (r *LocalVolumeDiscoveryReconciler) Reconcile(ctx context.Context, request
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	return ctrl.Result{}, nil
+}
 
-	klog.Info("deleting orphan discovery result instances")
-	err = r.deleteOrphanDiscoveryResults(ctx, instance)
-	if err != nil {
-		klog.ErrorS(err, "failed to delete orphan discovery results")
-		return ctrl.Result{}, err
+// isPaused returns true if the FusionAccess object in the namespace has reconciliation paused
+func (r *LocalVolumeDiscoveryReconciler) isPaused(ctx context.Context, namespace string) (bool, error) {
+	fusionAccessList := &localv1alpha1.FusionAccessList{}
+	if err := r.Client.List(ctx, fusionAccessList, client.InNamespace(namespace)); err != nil {
+		return false, fmt.Errorf("failed to list FusionAccess instances in namespace %q: %w", namespace, err)
 	}
+	for idx := range fusionAccessList.Items {
+		if fusionAccessList.Items[idx].IsPaused() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
 
-	return ctrl.Result{}, nil
+// getPauseRequests enqueues the LocalVolumeDiscovery instances next to a FusionAccess object, so that
+// they are reconciled again when its reconciliation is paused or resumed
+func (r *LocalVolumeDiscoveryReconciler) getPauseRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	discoveryList := &localv1alpha1.LocalVolumeDiscoveryList{}
+	if err := r.Client.List(ctx, discoveryList, client.InNamespace(obj.GetNamespace())); err != nil {
+		return []reconcile.Request{}
+	}
+	requests := []reconcile.Request{}
+	for idx := range discoveryList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&discoveryList.Items[idx])})
+	}
+	return requests
+}
+
+// getAllRequests enqueues every LocalVolumeDiscovery instance, so that the discovery daemonsets follow a
+// change of the operator configuration
+func (r *LocalVolumeDiscoveryReconciler) getAllRequests(ctx context.Context, _ client.Object) []reconcile.Request {
+	discoveryList := &localv1alpha1.LocalVolumeDiscoveryList{}
+	if err := r.Client.List(ctx, discoveryList); err != nil {
+		return []reconcile.Request{}
+	}
+	requests := []reconcile.Request{}
+	for idx := range discoveryList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&discoveryList.Items[idx])})
+	}
+	return requests
 }
 
 func getDeviceFinderDiscoveryDSMutateFn(request reconcile.Request,
@@ -155,6 +240,7 @@ func getDeviceFinderDiscoveryDSMutateFn(request reconcile.Request,
 				"${OBJECT_NAMESPACE}", request.Namespace,
 				"${CONTAINER_IMAGE}", common.GetDeviceFinderImage(),
 				"${RBAC_PROXY_IMAGE}", common.GetKubeRBACProxyImage(),
+				"${PRIORITY_CLASS_NAME}", common.GetPriorityClassName(),
 			},
 		)
 		if err != nil {
@@ -198,6 +284,8 @@ func (r *LocalVolumeDiscoveryReconciler) updateDiscoveryStatus(ctx context.Conte
 		if err != nil {
 			return err
 		}
+		event := discoveryEvents[conditionType]
+		r.recorder.Event(instance, event.eventType, event.reason, message)
 	}
 
 	return nil
@@ -285,8 +373,13 @@ func getEnvVars(objName, uid string) []corev1.EnvVar {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *LocalVolumeDiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = kubeutils.NewEventRecorder(mgr.GetEventRecorderFor(DeviceFinderDiscovery), kubeutils.DefaultEventInterval)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&localv1alpha1.LocalVolumeDiscovery{}).
 		Watches(&appsv1.DaemonSet{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &localv1alpha1.LocalVolumeDiscovery{})).
+		Watches(&localv1alpha1.FusionAccess{}, handler.EnqueueRequestsFromMapFunc(r.getPauseRequests),
+			builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Watches(&localv1alpha1.FusionAccessOperatorConfig{}, handler.EnqueueRequestsFromMapFunc(r.getAllRequests),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
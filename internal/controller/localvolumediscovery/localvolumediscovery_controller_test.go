@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	localv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
@@ -12,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -287,3 +289,79 @@ func TestDeleteOrphanDiscoveryResults(t *testing.T) {
 	assert.Equal(t, 1, len(results.Items))
 	assert.Equal(t, "Node1", results.Items[0].Spec.NodeName)
 }
+
+func TestDiscoveryReconcilerPaused(t *testing.T) {
+	discoveryDS := &appsv1.DaemonSet{}
+	discoveryDaemonSet.DeepCopyInto(discoveryDS)
+	discoveryDS.Spec.Template.Spec.NodeSelector = map[string]string{"unchanged": "true"}
+
+	discoveryObj := &localv1alpha1.LocalVolumeDiscovery{}
+	localVolumeDiscoveryCR.DeepCopyInto(discoveryObj)
+
+	fusionAccess := &localv1alpha1.FusionAccess{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "fusionaccess",
+			Namespace:   namespace,
+			Annotations: map[string]string{localv1alpha1.PausedAnnotation: "true"},
+		},
+	}
+	// The result on "Node3" is an orphan that must be kept while paused
+	orphan := &localv1alpha1.LocalVolumeDiscoveryResult{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery-result-node3", Namespace: namespace},
+		Spec:       localv1alpha1.LocalVolumeDiscoveryResultSpec{NodeName: "Node3"},
+	}
+
+	fakeReconciler := newFakeLocalVolumeDiscoveryReconciler(t, discoveryObj, discoveryDS, fusionAccess, orphan)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: discoveryObj.Name, Namespace: discoveryObj.Namespace}}
+	_, err := fakeReconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	// The daemonset was not updated but the status is still reported
+	ds := &appsv1.DaemonSet{}
+	err = fakeReconciler.Client.Get(context.TODO(), types.NamespacedName{Name: DeviceFinderDiscovery, Namespace: namespace}, ds)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"unchanged": "true"}, ds.Spec.Template.Spec.NodeSelector)
+	err = fakeReconciler.Client.Get(context.TODO(), req.NamespacedName, discoveryObj)
+	assert.NoError(t, err)
+	assert.Equal(t, "Available", discoveryObj.Status.Conditions[0].Type)
+	err = fakeReconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(orphan), orphan)
+	assert.NoError(t, err)
+
+	requests := fakeReconciler.getPauseRequests(context.TODO(), fusionAccess)
+	assert.Equal(t, []reconcile.Request{req}, requests)
+}
+
+func TestDiscoveryEvents(t *testing.T) {
+	discoveryDS := &appsv1.DaemonSet{}
+	discoveryDaemonSet.DeepCopyInto(discoveryDS)
+	discoveryDS.Status.NumberReady = 2
+	discoveryDS.Status.DesiredNumberScheduled = 3
+
+	discoveryObj := &localv1alpha1.LocalVolumeDiscovery{}
+	localVolumeDiscoveryCR.DeepCopyInto(discoveryObj)
+
+	fakeReconciler := newFakeLocalVolumeDiscoveryReconciler(t, discoveryObj, discoveryDS)
+	recorder := record.NewFakeRecorder(10)
+	fakeReconciler.recorder = kubeutils.NewEventRecorder(recorder, kubeutils.DefaultEventInterval)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: discoveryObj.Name, Namespace: discoveryObj.Namespace}}
+
+	// The event is only recorded when the status changes
+	for range 2 {
+		_, err := fakeReconciler.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+	}
+	assert.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Normal DiscoveryDaemonsProgressing running 2 out of 3 discovery daemons", <-recorder.Events)
+
+	err := fakeReconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(discoveryDS), discoveryDS)
+	assert.NoError(t, err)
+	discoveryDS.Status.NumberReady = 3
+	err = fakeReconciler.Client.Status().Update(context.TODO(), discoveryDS)
+	assert.NoError(t, err)
+	_, err = fakeReconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Len(t, recorder.Events, 1)
+	if len(recorder.Events) > 0 {
+		assert.Contains(t, <-recorder.Events, "Normal DiscoveryDaemonsReady ")
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/manifestival/manifestival"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+// manifestDriftCheckInterval is how often the live IBM resources are compared with the applied manifest
+var manifestDriftCheckInterval = 10 * time.Minute
+
+// manifestHash returns a content hash of the rendered manifest
+func manifestHash(installManifest manifestival.Manifest) (string, error) {
+	h := sha256.New()
+	for _, res := range installManifest.Resources() {
+		data, err := json.Marshal(res.Object)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash %s: %w", resourceID(&res), err)
+		}
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// reconcileManifest applies the manifest only when its content differs from the last one applied, and
+// otherwise periodically checks whether the IBM resources still match it. The returned result asks for a
+// requeue when the next drift check is due
+func (r *FusionAccessReconciler) reconcileManifest(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	installManifest manifestival.Manifest,
+) (ctrl.Result, error) {
+	hash, err := manifestHash(installManifest)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	applied := fusionaccess.Status.Manifest
+	if applied == nil || applied.Hash != hash {
+		if err := installManifest.Apply(); err != nil {
+			return ctrl.Result{}, err
+		}
+		setManifestApplied(fusionaccess, hash)
+		return ctrl.Result{RequeueAfter: manifestDriftCheckInterval}, nil
+	}
+	log.Log.Info("Storage Scale manifest is unchanged, skipping apply")
+
+	if applied.LastDriftCheckTime != nil {
+		if next := time.Until(applied.LastDriftCheckTime.Add(manifestDriftCheckInterval)); next > 0 {
+			return ctrl.Result{RequeueAfter: next}, nil
+		}
+	}
+
+	drifted, err := r.findManifestDrift(ctx, installManifest)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	now := v1.Now()
+	applied.LastDriftCheckTime = &now
+	applied.DriftedResources = drifted
+	switch {
+	case len(drifted) == 0:
+		setCondition(fusionaccess, fusionv1alpha1.ConditionManifestDrift, v1.ConditionFalse,
+			fusionv1alpha1.ReasonNoDrift, "All IBM resources match the applied manifest")
+	case fusionaccess.Spec.CorrectManifestDrift:
+		log.Log.Info("Correcting drifted IBM resources", "resources", drifted)
+		if err := installManifest.Apply(); err != nil {
+			return ctrl.Result{}, err
+		}
+		setCondition(fusionaccess, fusionv1alpha1.ConditionManifestDrift, v1.ConditionFalse,
+			fusionv1alpha1.ReasonDriftCorrected, fmt.Sprintf("Re-applied the manifest to correct: %s", strings.Join(drifted, ", ")))
+	default:
+		log.Log.Info("IBM resources drifted from the manifest", "resources", drifted)
+		setCondition(fusionaccess, fusionv1alpha1.ConditionManifestDrift, v1.ConditionTrue,
+			fusionv1alpha1.ReasonDriftDetected, fmt.Sprintf("Resources differ from the manifest: %s", strings.Join(drifted, ", ")))
+	}
+	return ctrl.Result{RequeueAfter: manifestDriftCheckInterval}, nil
+}
+
+// setManifestApplied records that the manifest with the given hash was just applied, which also
+// means that nothing has drifted from it
+func setManifestApplied(fusionaccess *fusionv1alpha1.FusionAccess, hash string) {
+	now := v1.Now()
+	fusionaccess.Status.Manifest = &fusionv1alpha1.ManifestStatus{
+		Hash:               hash,
+		LastDriftCheckTime: &now,
+	}
+	setCondition(fusionaccess, fusionv1alpha1.ConditionManifestDrift, v1.ConditionFalse,
+		fusionv1alpha1.ReasonNoDrift, "The manifest was just applied")
+}
+
+// findManifestDrift returns the resources of the manifest that are missing or whose live state does not
+// contain what the manifest sets. Fields that are only set on the live object, e.g. defaults, are ignored
+func (r *FusionAccessReconciler) findManifestDrift(ctx context.Context, installManifest manifestival.Manifest) ([]string, error) {
+	drifted := []string{}
+	for _, desired := range installManifest.Resources() {
+		live, err := r.getLiveObject(ctx, &desired)
+		if err != nil {
+			return nil, err
+		}
+		if live == nil {
+			drifted = append(drifted, fmt.Sprintf("%s (missing)", resourceID(&desired)))
+			continue
+		}
+		if len(resourceChanges(&desired, live)) > 0 {
+			drifted = append(drifted, resourceID(&desired))
+		}
+	}
+	return drifted, nil
+}
+
+// getLiveObject returns the object from the cluster or nil if it does not exist
+func (r *FusionAccessReconciler) getLiveObject(ctx context.Context, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(desired.GroupVersionKind())
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), live)
+	if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", resourceID(desired), err)
+	}
+	return live, nil
+}
+
+// resourceChanges returns the paths of the fields the manifest sets on a resource that have a different
+// value on the live object. The status and the server managed metadata are not compared
+func resourceChanges(desired, live *unstructured.Unstructured) []string {
+	changes := []string{}
+	for _, key := range slices.Sorted(maps.Keys(desired.Object)) {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			changes = append(changes, changedFields(desired.GetLabels(), live.GetLabels(), "metadata.labels")...)
+			changes = append(changes, changedFields(desired.GetAnnotations(), live.GetAnnotations(), "metadata.annotations")...)
+		default:
+			changes = append(changes, changedFields(desired.Object[key], live.Object[key], key)...)
+		}
+	}
+	return changes
+}
+
+// changedFields returns the paths of the fields set in desired that do not have the same value in live.
+// Lists have to match element by element
+func changedFields(desired, live any, path string) []string {
+	switch d := desired.(type) {
+	case nil:
+		return nil
+	case map[string]string:
+		l, _ := live.(map[string]string)
+		changes := []string{}
+		for _, k := range slices.Sorted(maps.Keys(d)) {
+			if lv, ok := l[k]; !ok || lv != d[k] {
+				changes = append(changes, fieldPath(path, k))
+			}
+		}
+		return changes
+	case map[string]any:
+		l, ok := live.(map[string]any)
+		if !ok {
+			if len(d) == 0 {
+				return nil
+			}
+			return []string{path}
+		}
+		changes := []string{}
+		for _, k := range slices.Sorted(maps.Keys(d)) {
+			changes = append(changes, changedFields(d[k], l[k], fieldPath(path, k))...)
+		}
+		return changes
+	case []any:
+		l, ok := live.([]any)
+		if !ok || len(l) != len(d) {
+			if len(d) == 0 && live == nil {
+				return nil
+			}
+			return []string{path}
+		}
+		changes := []string{}
+		for i := range d {
+			changes = append(changes, changedFields(d[i], l[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return changes
+	default:
+		if reflect.DeepEqual(desired, live) {
+			return nil
+		}
+		// Numbers may be decoded as int64 or float64 and quantities as numbers or strings
+		if live != nil && fmt.Sprint(desired) == fmt.Sprint(live) {
+			return nil
+		}
+		return []string{path}
+	}
+}
+
+func fieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func resourceID(res *unstructured.Unstructured) string {
+	if res.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", res.GetKind(), res.GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s", res.GetKind(), res.GetNamespace(), res.GetName())
+}
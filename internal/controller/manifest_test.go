@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	mfc "github.com/manifestival/controller-runtime-client"
+	"github.com/manifestival/manifestival"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+func newManifestConfigMap(value string) unstructured.Unstructured {
+	cm := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":      "ibm-spectrum-scale-manager-config",
+			"namespace": "ibm-spectrum-scale-operator",
+			"labels":    map[string]any{"app.kubernetes.io/name": "operator"},
+		},
+		"data": map[string]any{"key": value},
+	}}
+	return cm
+}
+
+var _ = Describe("IBM manifest apply and drift", func() {
+	var (
+		ctx = context.Background()
+		cl  client.Client
+		r   *FusionAccessReconciler
+	)
+
+	newManifest := func(resources ...unstructured.Unstructured) manifestival.Manifest {
+		m, err := manifestival.ManifestFrom(manifestival.Slice(resources), manifestival.UseClient(mfc.NewClient(cl)))
+		Expect(err).ToNot(HaveOccurred())
+		return m
+	}
+	getConfigMap := func() *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{}
+		Expect(cl.Get(ctx, types.NamespacedName{
+			Namespace: "ibm-spectrum-scale-operator", Name: "ibm-spectrum-scale-manager-config",
+		}, cm)).To(Succeed())
+		return cm
+	}
+
+	BeforeEach(func() {
+		r = newFakeReconciler(nil)
+		cl = r.Client
+	})
+
+	Describe("manifestHash", func() {
+		It("only changes with the content", func() {
+			first, err := manifestHash(newManifest(newManifestConfigMap("a")))
+			Expect(err).ToNot(HaveOccurred())
+			second, err := manifestHash(newManifest(newManifestConfigMap("a")))
+			Expect(err).ToNot(HaveOccurred())
+			changed, err := manifestHash(newManifest(newManifestConfigMap("b")))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(first).To(Equal(second))
+			Expect(first).ToNot(Equal(changed))
+		})
+	})
+
+	Describe("changedFields", func() {
+		It("ignores fields only set on the live object", func() {
+			desired := map[string]any{"spec": map[string]any{"replicas": int64(1)}}
+			live := map[string]any{"spec": map[string]any{"replicas": float64(1), "paused": false}}
+			Expect(changedFields(desired, live, "")).To(BeEmpty())
+		})
+
+		It("detects changed and removed fields", func() {
+			desired := map[string]any{"rules": []any{map[string]any{"verbs": []any{"get", "list"}}}}
+			Expect(changedFields(desired, map[string]any{"rules": []any{map[string]any{"verbs": []any{"get"}}}}, "")).
+				To(Equal([]string{"rules[0].verbs"}))
+			Expect(changedFields(desired, map[string]any{}, "")).To(Equal([]string{"rules"}))
+		})
+	})
+
+	Describe("reconcileManifest", func() {
+		It("applies the manifest once and skips it while unchanged", func() {
+			fusionaccess := &fusionv1alpha.FusionAccess{}
+			result, err := r.reconcileManifest(ctx, fusionaccess, newManifest(newManifestConfigMap("a")))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(manifestDriftCheckInterval))
+			Expect(fusionaccess.Status.Manifest).ToNot(BeNil())
+			Expect(getConfigMap().Data).To(HaveKeyWithValue("key", "a"))
+
+			// An edit is not undone before the next drift check
+			cm := getConfigMap()
+			cm.Data["key"] = "edited"
+			Expect(cl.Update(ctx, cm)).To(Succeed())
+			result, err = r.reconcileManifest(ctx, fusionaccess, newManifest(newManifestConfigMap("a")))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(getConfigMap().Data).To(HaveKeyWithValue("key", "edited"))
+
+			// A new manifest is applied right away
+			_, err = r.reconcileManifest(ctx, fusionaccess, newManifest(newManifestConfigMap("b")))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getConfigMap().Data).To(HaveKeyWithValue("key", "b"))
+		})
+
+		It("reports drift and corrects it when asked to", func() {
+			fusionaccess := &fusionv1alpha.FusionAccess{}
+			installManifest := newManifest(newManifestConfigMap("a"))
+			_, err := r.reconcileManifest(ctx, fusionaccess, installManifest)
+			Expect(err).ToNot(HaveOccurred())
+
+			cm := getConfigMap()
+			cm.Data["key"] = "edited"
+			Expect(cl.Update(ctx, cm)).To(Succeed())
+			past := metav1.NewTime(time.Now().Add(-2 * manifestDriftCheckInterval))
+			fusionaccess.Status.Manifest.LastDriftCheckTime = &past
+
+			_, err = r.reconcileManifest(ctx, fusionaccess, installManifest)
+			Expect(err).ToNot(HaveOccurred())
+			cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionManifestDrift)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Message).To(ContainSubstring("ConfigMap/ibm-spectrum-scale-operator/ibm-spectrum-scale-manager-config"))
+			Expect(fusionaccess.Status.Manifest.DriftedResources).To(HaveLen(1))
+			Expect(getConfigMap().Data).To(HaveKeyWithValue("key", "edited"))
+
+			fusionaccess.Spec.CorrectManifestDrift = true
+			fusionaccess.Status.Manifest.LastDriftCheckTime = &past
+			_, err = r.reconcileManifest(ctx, fusionaccess, installManifest)
+			Expect(err).ToNot(HaveOccurred())
+			cond = meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionManifestDrift)
+			Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonDriftCorrected))
+			Expect(getConfigMap().Data).To(HaveKeyWithValue("key", "a"))
+		})
+
+		It("reports deleted resources", func() {
+			fusionaccess := &fusionv1alpha.FusionAccess{}
+			installManifest := newManifest(newManifestConfigMap("a"))
+			_, err := r.reconcileManifest(ctx, fusionaccess, installManifest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cl.Delete(ctx, getConfigMap())).To(Succeed())
+
+			drifted, err := r.findManifestDrift(ctx, installManifest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifted).To(ConsistOf("ConfigMap/ibm-spectrum-scale-operator/ibm-spectrum-scale-manager-config (missing)"))
+		})
+	})
+})
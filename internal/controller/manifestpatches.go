@@ -0,0 +1,276 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/manifestival/manifestival"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+// The supported types of manifest patches
+const (
+	strategicMergePatch = "strategic"
+	json6902Patch       = "json6902"
+)
+
+// manifestPatch is a single entry of a ConfigMap referenced from spec.manifestPatches, e.g.
+//
+//	target:
+//	  kind: Deployment
+//	  name: ibm-spectrum-scale-controller-manager
+//	  namespace: ibm-spectrum-scale-operator
+//	patch: |
+//	  spec:
+//	    template:
+//	      spec:
+//	        nodeSelector:
+//	          node-role.kubernetes.io/infra: ""
+type manifestPatch struct {
+	Target struct {
+		Kind      string `yaml:"kind"`
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"target"`
+	// Type is "strategic" (the default) or "json6902". Strategic merge patches on kinds that have no
+	// patch strategy, like custom resources, are applied as JSON merge patches
+	Type string `yaml:"type"`
+	// Patch is the patch in YAML or JSON
+	Patch string `yaml:"patch"`
+
+	// source is the ConfigMap and key the patch was read from
+	source string
+	// patch is the patch converted to JSON
+	json []byte
+	// matched counts the manifest objects the patch was applied to
+	matched int
+}
+
+// patchManifest layers the patches from the ConfigMaps referenced in the spec onto the manifest
+func (r *FusionAccessReconciler) patchManifest(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	installManifest manifestival.Manifest,
+) (manifestival.Manifest, int, error) {
+	patches, err := r.getManifestPatches(ctx, fusionaccess)
+	if err != nil || len(patches) == 0 {
+		return installManifest, 0, err
+	}
+
+	transformers := make([]manifestival.Transformer, 0, len(patches))
+	for _, patch := range patches {
+		transformers = append(transformers, patch.transformer(r.Scheme))
+	}
+	patched, err := installManifest.Transform(transformers...)
+	if err != nil {
+		return installManifest, 0, err
+	}
+	unmatched := []string{}
+	for _, patch := range patches {
+		if patch.matched == 0 {
+			unmatched = append(unmatched, patch.source)
+		}
+	}
+	if len(unmatched) > 0 {
+		return installManifest, 0, fmt.Errorf("patches do not match any object of the manifest: %s", strings.Join(unmatched, ", "))
+	}
+	return patched, len(patches), nil
+}
+
+// reconcileManifestPatches patches the manifest and reports the outcome in the ManifestPatches condition
+func (r *FusionAccessReconciler) reconcileManifestPatches(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	installManifest manifestival.Manifest,
+) (manifestival.Manifest, error) {
+	patched, count, err := r.patchManifest(ctx, fusionaccess, installManifest)
+	switch {
+	case err != nil:
+		log.Log.Error(err, "Invalid manifest patches")
+		setCondition(fusionaccess, fusionv1alpha1.ConditionManifestPatches, v1.ConditionFalse,
+			fusionv1alpha1.ReasonPatchesInvalid, err.Error())
+		if serr := r.updateStatus(ctx, fusionaccess); serr != nil {
+			return installManifest, errors.Join(serr, err)
+		}
+		return installManifest, err
+	case count == 0:
+		setCondition(fusionaccess, fusionv1alpha1.ConditionManifestPatches, v1.ConditionTrue,
+			fusionv1alpha1.ReasonNotApplicable, "No manifest patches are configured")
+	default:
+		setCondition(fusionaccess, fusionv1alpha1.ConditionManifestPatches, v1.ConditionTrue,
+			fusionv1alpha1.ReasonPatchesApplied, fmt.Sprintf("%d patches were layered onto the manifest", count))
+	}
+	return patched, nil
+}
+
+// getManifestPatches reads and validates all the patches of the ConfigMaps referenced in the spec.
+// The entries of each ConfigMap are applied in the order of their keys
+func (r *FusionAccessReconciler) getManifestPatches(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) ([]*manifestPatch, error) {
+	patches := []*manifestPatch{}
+	errs := []error{}
+	for _, ref := range fusionaccess.Spec.ManifestPatches {
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: fusionaccess.Namespace, Name: ref.Name}, cm); err != nil {
+			errs = append(errs, fmt.Errorf("failed to get ConfigMap %s: %w", ref.Name, err))
+			continue
+		}
+		for _, key := range slices.Sorted(maps.Keys(cm.Data)) {
+			patch, err := parseManifestPatch(fmt.Sprintf("%s/%s", cm.Name, key), cm.Data[key])
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			patches = append(patches, patch)
+		}
+	}
+	return patches, errors.Join(errs...)
+}
+
+func parseManifestPatch(source, data string) (*manifestPatch, error) {
+	patch := &manifestPatch{source: source}
+	if err := yaml.Unmarshal([]byte(data), patch); err != nil {
+		return nil, fmt.Errorf("%s: invalid patch: %w", source, err)
+	}
+	if patch.Target.Kind == "" || patch.Target.Name == "" {
+		return nil, fmt.Errorf("%s: the target kind and name are required", source)
+	}
+	var content any
+	if err := yaml.Unmarshal([]byte(patch.Patch), &content); err != nil || content == nil {
+		return nil, fmt.Errorf("%s: the patch is not valid YAML or JSON: %v", source, err)
+	}
+	var err error
+	if patch.json, err = json.Marshal(content); err != nil {
+		return nil, fmt.Errorf("%s: the patch can't be converted to JSON: %w", source, err)
+	}
+
+	switch patch.Type {
+	case "":
+		patch.Type = strategicMergePatch
+		fallthrough
+	case strategicMergePatch:
+		if _, ok := content.(map[string]any); !ok {
+			return nil, fmt.Errorf("%s: a strategic merge patch must be an object", source)
+		}
+	case json6902Patch:
+		if _, err := jsonpatch.DecodePatch(patch.json); err != nil {
+			return nil, fmt.Errorf("%s: invalid JSON6902 patch: %w", source, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s: unknown patch type %q, use %q or %q", source, patch.Type, strategicMergePatch, json6902Patch)
+	}
+	return patch, nil
+}
+
+func (p *manifestPatch) matches(u *unstructured.Unstructured) bool {
+	return u.GetKind() == p.Target.Kind && u.GetName() == p.Target.Name &&
+		(p.Target.Namespace == "" || u.GetNamespace() == p.Target.Namespace)
+}
+
+// transformer applies the patch to the manifest object it targets
+func (p *manifestPatch) transformer(scheme *runtime.Scheme) manifestival.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if !p.matches(u) {
+			return nil
+		}
+		p.matched++
+		original, err := json.Marshal(u.Object)
+		if err != nil {
+			return err
+		}
+
+		var patched []byte
+		switch p.Type {
+		case json6902Patch:
+			patch, err := jsonpatch.DecodePatch(p.json)
+			if err != nil {
+				return fmt.Errorf("%s: %w", p.source, err)
+			}
+			patched, err = patch.Apply(original)
+			if err != nil {
+				return fmt.Errorf("%s: failed to apply the patch to %s: %w", p.source, resourceID(u), err)
+			}
+		default:
+			if typed, terr := scheme.New(u.GroupVersionKind()); terr == nil {
+				patched, err = strategicpatch.StrategicMergePatch(original, p.json, typed)
+			} else {
+				patched, err = jsonpatch.MergePatch(original, p.json)
+			}
+			if err != nil {
+				return fmt.Errorf("%s: failed to apply the patch to %s: %w", p.source, resourceID(u), err)
+			}
+		}
+
+		object := map[string]any{}
+		if err := json.Unmarshal(patched, &object); err != nil {
+			return err
+		}
+		u.Object = object
+		log.Log.Info(fmt.Sprintf("Patched %s with %s", resourceID(u), p.source))
+		return nil
+	}
+}
+
+// getManifestConfigMapRequests enqueues the FusionAccess instance when one of the ConfigMaps its manifest
+// is loaded or patched from changes
+func (r *FusionAccessReconciler) getManifestConfigMapRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	fusionAccessList := &fusionv1alpha1.FusionAccessList{}
+	if err := r.List(ctx, fusionAccessList, client.InNamespace(obj.GetNamespace())); err != nil {
+		return []reconcile.Request{}
+	}
+	requests := []reconcile.Request{}
+	for _, fusionaccess := range fusionAccessList.Items {
+		if slices.Contains(manifestConfigMaps(fusionaccess.Spec), obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&fusionaccess)})
+		}
+	}
+	return requests
+}
+
+// manifestConfigMaps returns the names of the ConfigMaps the manifest is loaded or patched from
+func manifestConfigMaps(spec fusionv1alpha1.FusionAccessSpec) []string {
+	refs := slices.Clone(spec.ManifestPatches)
+	if source := spec.ManifestSource; source != nil {
+		refs = append(refs, source.ConfigMaps...)
+		if source.OCIArtifact != nil && source.OCIArtifact.CABundle != nil {
+			refs = append(refs, *source.OCIArtifact.CABundle)
+		}
+	}
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return names
+}
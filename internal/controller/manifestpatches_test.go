@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	mfc "github.com/manifestival/controller-runtime-client"
+	"github.com/manifestival/manifestival"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
+)
+
+func newManifestDeployment() unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":      "ibm-spectrum-scale-controller-manager",
+			"namespace": "ibm-spectrum-scale-operator",
+		},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "manager", "image": "operator:1", "env": []any{
+							map[string]any{"name": "EXISTING", "value": "1"},
+						}},
+						map[string]any{"name": "proxy", "image": "proxy:1"},
+					},
+				},
+			},
+		},
+	}}
+}
+
+var _ = Describe("Manifest patches", func() {
+	const ns = "ibm-fusion-access-operator"
+	var (
+		ctx          = context.Background()
+		cl           client.Client
+		r            *FusionAccessReconciler
+		fusionaccess *fusionv1alpha.FusionAccess
+	)
+
+	newPatches := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "patches", Namespace: ns}, Data: data}
+	}
+	setup := func(objs ...client.Object) manifestival.Manifest {
+		r = newFakeReconciler(objs)
+		cl = r.Client
+		m, err := manifestival.ManifestFrom(
+			manifestival.Slice([]unstructured.Unstructured{newManifestDeployment(), newManifestConfigMap("a")}),
+			manifestival.UseClient(mfc.NewClient(cl)))
+		Expect(err).ToNot(HaveOccurred())
+		return m
+	}
+
+	BeforeEach(func() {
+		fusionaccess = &fusionv1alpha.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: ns},
+			Spec: fusionv1alpha.FusionAccessSpec{
+				ManifestPatches: []corev1.LocalObjectReference{{Name: "patches"}},
+			},
+		}
+	})
+
+	It("merges strategic patches into the targeted object", func() {
+		installManifest := setup(newPatches(map[string]string{
+			"operator.yaml": `
+target:
+  kind: Deployment
+  name: ibm-spectrum-scale-controller-manager
+patch: |
+  spec:
+    template:
+      spec:
+        nodeSelector:
+          node-role.kubernetes.io/infra: ""
+        containers:
+        - name: manager
+          env:
+          - name: EXTRA
+            value: "2"
+`,
+		}))
+
+		patched, count, err := r.patchManifest(ctx, fusionaccess, installManifest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(1))
+		deployment := patched.Filter(manifestival.ByKind("Deployment")).Resources()[0]
+		nodeSelector, _, _ := unstructured.NestedStringMap(deployment.Object, "spec", "template", "spec", "nodeSelector")
+		Expect(nodeSelector).To(HaveKey("node-role.kubernetes.io/infra"))
+		containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+		// Containers are merged by name, the other container and the existing env are kept
+		Expect(containers).To(HaveLen(2))
+		env, _, _ := unstructured.NestedSlice(containers[0].(map[string]any), "env")
+		Expect(env).To(HaveLen(2))
+
+		// The original manifest is left untouched
+		original := installManifest.Filter(manifestival.ByKind("Deployment")).Resources()[0]
+		_, found, _ := unstructured.NestedStringMap(original.Object, "spec", "template", "spec", "nodeSelector")
+		Expect(found).To(BeFalse())
+	})
+
+	It("applies JSON6902 patches", func() {
+		installManifest := setup(newPatches(map[string]string{
+			"config.yaml": `
+target:
+  kind: ConfigMap
+  name: ibm-spectrum-scale-manager-config
+  namespace: ibm-spectrum-scale-operator
+type: json6902
+patch: |
+  - op: replace
+    path: /data/key
+    value: patched
+`,
+		}))
+
+		patched, _, err := r.patchManifest(ctx, fusionaccess, installManifest)
+		Expect(err).ToNot(HaveOccurred())
+		cm := patched.Filter(manifestival.ByKind("ConfigMap")).Resources()[0]
+		value, _, _ := unstructured.NestedString(cm.Object, "data", "key")
+		Expect(value).To(Equal("patched"))
+	})
+
+	It("reports invalid patches in the condition", func() {
+		installManifest := setup(fusionaccess, newPatches(map[string]string{
+			"a-unknown-type.yaml": "target: {kind: ConfigMap, name: ibm-spectrum-scale-manager-config}\ntype: merge\npatch: '{}'",
+			"b-no-target.yaml":    "patch: '{}'",
+		}))
+
+		_, err := r.reconcileManifestPatches(ctx, fusionaccess, installManifest)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("patches/a-unknown-type.yaml: unknown patch type"))
+		Expect(err.Error()).To(ContainSubstring("patches/b-no-target.yaml: the target kind and name are required"))
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionManifestPatches)
+		Expect(cond).ToNot(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonPatchesInvalid))
+	})
+
+	It("rejects patches that do not match any object", func() {
+		installManifest := setup(newPatches(map[string]string{
+			"typo.yaml": "target: {kind: Deployment, name: ibm-spectrum-scale-controler-manager}\npatch: '{}'",
+		}))
+
+		_, _, err := r.patchManifest(ctx, fusionaccess, installManifest)
+		Expect(err).To(MatchError(ContainSubstring("do not match any object of the manifest: patches/typo.yaml")))
+	})
+
+	It("reports a missing ConfigMap", func() {
+		installManifest := setup()
+		_, _, err := r.patchManifest(ctx, fusionaccess, installManifest)
+		Expect(err).To(MatchError(ContainSubstring("failed to get ConfigMap patches")))
+	})
+
+	It("enqueues the FusionAccess that references a changed ConfigMap", func() {
+		setup(fusionaccess)
+		Expect(r.getManifestConfigMapRequests(ctx, newPatches(nil))).To(HaveLen(1))
+		other := newPatches(nil)
+		other.Name = "other"
+		Expect(r.getManifestConfigMapRequests(ctx, other)).To(BeEmpty())
+	})
+
+	It("enqueues the FusionAccess once for a ConfigMap it references and created", func() {
+		setup(fusionaccess)
+		patches := newPatches(nil)
+		Expect(kubeutils.SetOwner(fusionaccess, patches, createFakeScheme())).To(Succeed())
+		Expect(r.getConfigMapRequests(ctx, patches)).To(HaveLen(1))
+		owned := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "owned", Namespace: ns}}
+		Expect(kubeutils.SetOwner(fusionaccess, owned, createFakeScheme())).To(Succeed())
+		Expect(r.getConfigMapRequests(ctx, owned)).To(HaveLen(1))
+	})
+
+	It("watches the ConfigMaps of a FusionAccess outside the operator namespace", func() {
+		fusionaccess.Namespace = "fusion-access"
+		setup(fusionaccess)
+		Expect(r.hasFusionAccess(ctx, "fusion-access")).To(BeTrue())
+		Expect(r.hasFusionAccess(ctx, ns)).To(BeFalse())
+		patches := newPatches(nil)
+		patches.Namespace = "fusion-access"
+		Expect(r.getManifestConfigMapRequests(ctx, patches)).To(HaveLen(1))
+		Expect(r.getManifestConfigMapRequests(ctx, newPatches(nil))).To(BeEmpty())
+	})
+})
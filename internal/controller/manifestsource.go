@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+const (
+	// manifestSourceKey is the ConfigMap key that holds the manifest or a chunk of it
+	manifestSourceKey = "install.yaml"
+	// caBundleKey is the ConfigMap key that holds the CA certificates of a registry
+	caBundleKey = "ca-bundle.crt"
+)
+
+// loadManifestSource loads the manifest from the ConfigMaps or the OCI artifact of the manifest source
+func (r *FusionAccessReconciler) loadManifestSource(
+	ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, source *fusionv1alpha1.ManifestSource,
+) (*externalManifest, error) {
+	switch {
+	case len(source.ConfigMaps) > 0:
+		names := make([]string, 0, len(source.ConfigMaps))
+		for _, ref := range source.ConfigMaps {
+			names = append(names, ref.Name)
+		}
+		return r.loadCachedManifest(ctx, fusionaccess, fmt.Sprintf("ConfigMaps %s", strings.Join(names, ", ")), "", 0, !isDryRun(fusionaccess),
+			func() ([]byte, error) {
+				return r.readManifestConfigMaps(ctx, fusionaccess.Namespace, names)
+			})
+	case source.OCIArtifact != nil:
+		artifact := source.OCIArtifact
+		// A digest always points to the same artifact, while a tag is only pulled again at the drift check
+		// interval rather than on every reconcile
+		maxAge := manifestDriftCheckInterval
+		if ref, err := parseImageReference(artifact.Image); err == nil && strings.HasPrefix(ref.Reference, "sha256:") {
+			maxAge = immutableManifest
+		}
+		return r.loadCachedManifest(ctx, fusionaccess, fmt.Sprintf("OCI artifact %s", artifact.Image), "", maxAge, !isDryRun(fusionaccess),
+			func() ([]byte, error) {
+				return r.pullManifestArtifact(ctx, fusionaccess.Namespace, artifact)
+			})
+	}
+	return nil, fmt.Errorf("the manifest source sets neither configMaps nor ociArtifact")
+}
+
+// readManifestConfigMaps concatenates the manifest chunks of the ConfigMaps in order. A chunk may end
+// anywhere, even in the middle of a line
+func (r *FusionAccessReconciler) readManifestConfigMaps(ctx context.Context, ns string, names []string) ([]byte, error) {
+	var manifest strings.Builder
+	for _, name := range names {
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, cm); err != nil {
+			return nil, fmt.Errorf("failed to get manifest ConfigMap %s: %w", name, err)
+		}
+		if chunk, ok := cm.Data[manifestSourceKey]; ok {
+			manifest.WriteString(chunk)
+		} else if chunk, ok := cm.BinaryData[manifestSourceKey]; ok {
+			manifest.Write(chunk)
+		} else {
+			return nil, fmt.Errorf("manifest ConfigMap %s has no %s key", name, manifestSourceKey)
+		}
+	}
+	return []byte(manifest.String()), nil
+}
+
+// pullManifestArtifact pulls the OCI artifact with the credentials of its pull secret, or of the global
+// pull secret when none is set
+func (r *FusionAccessReconciler) pullManifestArtifact(ctx context.Context, ns string, artifact *fusionv1alpha1.OCIArtifactSource) ([]byte, error) {
+	secretKey := types.NamespacedName{Namespace: "openshift-config", Name: "pull-secret"}
+	if artifact.PullSecret != nil {
+		secretKey = types.NamespacedName{Namespace: ns, Name: artifact.PullSecret.Name}
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, secretKey, secret); err != nil {
+		return nil, fmt.Errorf("failed to get pull secret %s: %w", secretKey, err)
+	}
+
+	var caBundle []byte
+	if artifact.CABundle != nil {
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: ns, Name: artifact.CABundle.Name}, cm); err != nil {
+			return nil, fmt.Errorf("failed to get CA bundle ConfigMap %s: %w", artifact.CABundle.Name, err)
+		}
+		caBundle = []byte(cm.Data[caBundleKey])
+	}
+	return pullOCIArtifact(ctx, artifact.Image, secret.Data[corev1.DockerConfigJsonKey], caBundle)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("Manifest source", func() {
+	// The FusionAccess is not in the namespace of the operator, its ConfigMaps and secrets are read from its own
+	const ns = "fusion-access"
+	var (
+		ctx          = context.Background()
+		cl           client.Client
+		r            *FusionAccessReconciler
+		fusionaccess *fusionv1alpha.FusionAccess
+	)
+
+	newChunk := func(name, chunk string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Data:       map[string]string{manifestSourceKey: chunk},
+		}
+	}
+	setup := func(objs ...client.Object) {
+		r = newFakeReconciler(append(objs, fusionaccess), withStatusSubresource(&fusionv1alpha.FusionAccess{}))
+		cl = r.Client
+	}
+	loaded := func(path string) string {
+		data, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		return string(data)
+	}
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", "ibm-fusion-access-operator")
+		cacheDir := externalManifestCacheDir
+		externalManifestCacheDir = GinkgoT().TempDir()
+		DeferCleanup(func() { externalManifestCacheDir = cacheDir })
+
+		fusionaccess = &fusionv1alpha.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: ns},
+			Spec: fusionv1alpha.FusionAccessSpec{
+				ManifestSource: &fusionv1alpha.ManifestSource{
+					ConfigMaps: []corev1.LocalObjectReference{{Name: "manifest-1"}, {Name: "manifest-2"}},
+				},
+			},
+		}
+	})
+
+	It("concatenates the chunks of the ConfigMaps in order", func() {
+		split := len(testExternalManifest) / 2
+		setup(newChunk("manifest-1", testExternalManifest[:split]), newChunk("manifest-2", testExternalManifest[split:]))
+
+		path, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loaded(path)).To(Equal(testExternalManifest))
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonManifestLoaded))
+		Expect(cond.Message).To(HavePrefix("Loaded the manifest from ConfigMaps manifest-1, manifest-2"))
+	})
+
+	It("uses the cached copy once a ConfigMap is gone", func() {
+		split := len(testExternalManifest) / 2
+		chunk := newChunk("manifest-2", testExternalManifest[split:])
+		setup(newChunk("manifest-1", testExternalManifest[:split]), chunk)
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cl.Delete(ctx, chunk)).To(Succeed())
+		path, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loaded(path)).To(Equal(testExternalManifest))
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonCachedManifestUsed))
+	})
+
+	It("rejects a ConfigMap without the manifest key", func() {
+		other := newChunk("manifest-2", "")
+		other.Data = map[string]string{"other.yaml": ""}
+		setup(newChunk("manifest-1", testExternalManifest), other)
+
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).To(MatchError(ContainSubstring("manifest ConfigMap manifest-2 has no install.yaml key")))
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonManifestFetchFailed))
+	})
+
+	It("rejects a manifest without the IBM operator configuration", func() {
+		fusionaccess.Spec.ManifestSource.ConfigMaps = fusionaccess.Spec.ManifestSource.ConfigMaps[:1]
+		setup(newChunk("manifest-1", "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: ibm-spectrum-scale\n"))
+
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).To(MatchError(errInvalidManifest))
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonManifestInvalid))
+	})
+
+	// setupArtifact serves the manifest as an OCI artifact and returns the registry and the image by digest
+	setupArtifact := func() (*httptest.Server, string) {
+		server, image, caBundle := newTestRegistry([]byte(testExternalManifest), "install.yaml")
+		dockerConfig := fmt.Sprintf(testRegistryDockerConfig, strings.TrimPrefix(server.URL, "https://"))
+		fusionaccess.Spec.ManifestSource = &fusionv1alpha.ManifestSource{
+			OCIArtifact: &fusionv1alpha.OCIArtifactSource{
+				Image:      image,
+				PullSecret: &corev1.LocalObjectReference{Name: "mirror-pull-secret"},
+				CABundle:   &corev1.LocalObjectReference{Name: "mirror-ca"},
+			},
+		}
+		setup(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "mirror-pull-secret", Namespace: ns},
+				Type:       corev1.SecretTypeDockerConfigJson,
+				Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(dockerConfig)},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "mirror-ca", Namespace: ns},
+				Data:       map[string]string{caBundleKey: string(caBundle)},
+			},
+		)
+		return server, image
+	}
+
+	It("pulls the manifest from an OCI artifact with the pull secret and CA bundle", func() {
+		setupArtifact()
+
+		path, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loaded(path)).To(Equal(testExternalManifest))
+		Expect(meta.IsStatusConditionTrue(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)).To(BeTrue())
+	})
+
+	It("does not pull an OCI artifact by digest again", func() {
+		server, _ := setupArtifact()
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+
+		server.Close()
+		path, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loaded(path)).To(Equal(testExternalManifest))
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonManifestLoaded))
+	})
+
+	It("pulls an OCI artifact by tag again only at the drift check interval", func() {
+		server, image := setupArtifact()
+		fusionaccess.Spec.ManifestSource.OCIArtifact.Image = image[:strings.Index(image, "@")] + ":v1"
+		_, err := r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+
+		server.Close()
+		_, err = r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonManifestLoaded))
+
+		cm := &corev1.ConfigMap{}
+		Expect(cl.Get(ctx, types.NamespacedName{Namespace: ns, Name: manifestCacheConfigMap}, cm)).To(Succeed())
+		cm.Data[manifestCacheLoadedKey] = time.Now().Add(-manifestDriftCheckInterval).UTC().Format(time.RFC3339)
+		Expect(cl.Update(ctx, cm)).To(Succeed())
+		_, err = r.reconcileExternalManifest(ctx, fusionaccess)
+		Expect(err).ToNot(HaveOccurred())
+		cond = meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionExternalManifest)
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonCachedManifestUsed))
+	})
+
+	It("watches the ConfigMaps of the manifest source", func() {
+		fusionaccess.Spec.ManifestPatches = []corev1.LocalObjectReference{{Name: "patches"}}
+		setup()
+		Expect(r.getManifestConfigMapRequests(ctx, newChunk("manifest-2", ""))).To(HaveLen(1))
+		Expect(r.getManifestConfigMapRequests(ctx, newChunk("patches", ""))).To(HaveLen(1))
+		Expect(r.getManifestConfigMapRequests(ctx, newChunk("other", ""))).To(BeEmpty())
+	})
+})
@@ -0,0 +1,391 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	ociManifestMediaType        = "application/vnd.oci.image.manifest.v1+json"
+	ociIndexMediaType           = "application/vnd.oci.image.index.v1+json"
+	dockerManifestMediaType     = "application/vnd.docker.distribution.manifest.v2+json"
+	dockerManifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+	// ociTitleAnnotation is the file name oras records for each layer it pushes
+	ociTitleAnnotation = "org.opencontainers.image.title"
+)
+
+// imageReference is a parsed "registry/repository[:tag|@digest]" reference
+type imageReference struct {
+	Registry   string
+	Repository string
+	// Reference is the tag or the digest
+	Reference string
+}
+
+// ociDescriptor points to a blob in a registry
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociManifest is the part of an OCI image manifest needed to find the layers
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// registryClient reads from a single repository of a registry with the distribution API
+type registryClient struct {
+	client             *http.Client
+	base               string
+	username, password string
+	// authorization is the Authorization header value, set once the registry asked for credentials
+	authorization string
+}
+
+// pullOCIArtifact downloads the manifest stored in the layer of an OCI artifact. The artifact is verified
+// against the digest of the reference, if any, and every blob against its descriptor
+func pullOCIArtifact(ctx context.Context, image string, dockerConfig, caBundle []byte) ([]byte, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return nil, err
+	}
+	c, err := newRegistryClient(ref, dockerConfig, caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := c.get(ctx, "/manifests/"+ref.Reference, ociManifestMediaType+", "+dockerManifestMediaType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the manifest of %s: %w", image, err)
+	}
+	if strings.HasPrefix(ref.Reference, "sha256:") && manifestDigest(raw) != ref.Reference {
+		return nil, fmt.Errorf("the manifest of %s has digest %s", image, manifestDigest(raw))
+	}
+	manifest := &ociManifest{}
+	if err := json.Unmarshal(raw, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse the manifest of %s: %w", image, err)
+	}
+	layer, err := manifestLayer(manifest.Layers)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", image, err)
+	}
+
+	blob, err := c.get(ctx, "/blobs/"+layer.Digest, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get layer %s of %s: %w", layer.Digest, image, err)
+	}
+	if manifestDigest(blob) != layer.Digest {
+		return nil, fmt.Errorf("layer %s of %s has digest %s", layer.Digest, image, manifestDigest(blob))
+	}
+	return unpackLayer(blob)
+}
+
+// resolveImageDigest returns the digest of the manifest an image reference points to. A reference by digest
+// is returned as is, a tag is looked up in the registry
+func resolveImageDigest(ctx context.Context, image string, dockerConfig, caBundle []byte) (string, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(ref.Reference, "sha256:") {
+		return ref.Reference, nil
+	}
+	c, err := newRegistryClient(ref, dockerConfig, caBundle)
+	if err != nil {
+		return "", err
+	}
+	raw, err := c.get(ctx, "/manifests/"+ref.Reference,
+		strings.Join([]string{ociIndexMediaType, dockerManifestListMediaType, ociManifestMediaType, dockerManifestMediaType}, ", "))
+	if err != nil {
+		return "", fmt.Errorf("failed to get the manifest of %s: %w", image, err)
+	}
+	return manifestDigest(raw), nil
+}
+
+// newRegistryClient returns a client for the repository of the reference, with the credentials found for it
+// in the .dockerconfigjson and trusting the CA bundle on top of the system certificates
+func newRegistryClient(ref *imageReference, dockerConfig, caBundle []byte) (*registryClient, error) {
+	username, password, err := registryCredentials(dockerConfig, ref.Registry, ref.Repository)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if len(caBundle) > 0 && !pool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("the CA bundle of %s does not contain any certificate", ref.Registry)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return &registryClient{
+		client:   &http.Client{Timeout: 30 * time.Second, Transport: transport},
+		base:     fmt.Sprintf("https://%s/v2/%s", ref.Registry, ref.Repository),
+		username: username,
+		password: password,
+	}, nil
+}
+
+// parseImageReference splits an image reference. The registry must be explicit, as there is no sensible
+// default registry on a disconnected cluster
+func parseImageReference(image string) (*imageReference, error) {
+	name, reference := image, "latest"
+	if i := strings.Index(image, "@"); i >= 0 {
+		name, reference = image[:i], image[i+1:]
+		if !strings.HasPrefix(reference, "sha256:") {
+			return nil, fmt.Errorf("unsupported digest in image %s, only sha256 is supported", image)
+		}
+	} else if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, reference = image[:i], image[i+1:]
+	}
+	registry, repository, ok := strings.Cut(name, "/")
+	if !ok || repository == "" || (!strings.ContainsAny(registry, ".:") && registry != "localhost") {
+		return nil, fmt.Errorf("image %s must be of the form registry/repository[:tag|@digest]", image)
+	}
+	return &imageReference{Registry: registry, Repository: repository, Reference: reference}, nil
+}
+
+// registryCredentials returns the credentials for the repository from a .dockerconfigjson. As in the
+// global pull secret, an entry may be for a whole registry or for a namespace in it, the most specific wins
+func registryCredentials(dockerConfig []byte, registry, repository string) (string, string, error) {
+	if len(dockerConfig) == 0 {
+		return "", "", nil
+	}
+	config := struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}{}
+	if err := json.Unmarshal(dockerConfig, &config); err != nil {
+		return "", "", fmt.Errorf("invalid .dockerconfigjson: %w", err)
+	}
+
+	target := registry + "/" + repository
+	best, bestScope := "", ""
+	for key := range config.Auths {
+		scope := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://"), "/")
+		if (scope == target || strings.HasPrefix(target, scope+"/")) && len(scope) > len(bestScope) {
+			best, bestScope = key, scope
+		}
+	}
+	if best == "" {
+		return "", "", nil
+	}
+	entry := config.Auths[best]
+	if entry.Auth == "" {
+		return entry.Username, entry.Password, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid auth for %s: %w", best, err)
+	}
+	username, password, _ := strings.Cut(string(decoded), ":")
+	return username, password, nil
+}
+
+// manifestLayer picks the layer that holds the manifest: the one with a YAML file name, or the only one
+func manifestLayer(layers []ociDescriptor) (*ociDescriptor, error) {
+	for i := range layers {
+		switch path.Ext(layers[i].Annotations[ociTitleAnnotation]) {
+		case ".yaml", ".yml":
+			return &layers[i], nil
+		}
+	}
+	if len(layers) == 1 {
+		return &layers[0], nil
+	}
+	return nil, fmt.Errorf("the artifact has %d layers and none of them is titled as a YAML file", len(layers))
+}
+
+// unpackLayer returns the manifest from a layer. oras stores a single file as is, but a layer may also be
+// a possibly compressed tar archive
+func unpackLayer(blob []byte) ([]byte, error) {
+	if bytes.HasPrefix(blob, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(bytes.NewReader(blob))
+		if err != nil {
+			return nil, err
+		}
+		if blob, err = io.ReadAll(io.LimitReader(gz, maxExternalManifestSize+1)); err != nil {
+			return nil, err
+		}
+	}
+	if len(blob) > maxExternalManifestSize {
+		return nil, fmt.Errorf("the manifest is larger than %d bytes", maxExternalManifestSize)
+	}
+	// A tar archive has its magic at offset 257 of the first header
+	if len(blob) < 262 || string(blob[257:262]) != "ustar" {
+		return blob, nil
+	}
+	archive := tar.NewReader(bytes.NewReader(blob))
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("the layer archive does not contain a YAML file")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ext := path.Ext(header.Name); header.Typeflag == tar.TypeReg && (ext == ".yaml" || ext == ".yml") {
+			return io.ReadAll(archive)
+		}
+	}
+}
+
+// get reads a manifest or a blob, authenticating first if the registry asks for it
+func (c *registryClient) get(ctx context.Context, subpath, accept string) ([]byte, error) {
+	resp, err := c.do(ctx, c.base+subpath, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.authorization == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := c.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = c.do(ctx, c.base+subpath, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxExternalManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxExternalManifestSize {
+		return nil, fmt.Errorf("the response is larger than %d bytes", maxExternalManifestSize)
+	}
+	return data, nil
+}
+
+func (c *registryClient) do(ctx context.Context, target, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	return c.client.Do(req)
+}
+
+// authenticate answers a Basic or Bearer challenge of the registry
+func (c *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params := parseAuthChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.username == "" {
+			return fmt.Errorf("the registry requires credentials and the pull secret has none")
+		}
+		c.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password))
+		return nil
+	case "bearer":
+	default:
+		return fmt.Errorf("unsupported registry authentication %q", challenge)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" {
+		return fmt.Errorf("invalid token realm in %q", challenge)
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get a registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get a registry token: unexpected HTTP status %s", resp.Status)
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("failed to parse the registry token: %w", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	c.authorization = "Bearer " + token.Token
+	return nil
+}
+
+// parseAuthChallenge splits a WWW-Authenticate header like `Bearer realm="...",service="..."`
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest != "" {
+		key, value, ok := strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if !ok {
+			break
+		}
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[strings.ToLower(key)] = value[1:]
+				break
+			}
+			params[strings.ToLower(key)] = value[1 : end+1]
+			rest = value[end+2:]
+		} else {
+			value, rest, _ = strings.Cut(value, ",")
+			params[strings.ToLower(key)] = value
+		}
+	}
+	return scheme, params
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const testRegistryDockerConfig = `{"auths":{"registry.example.com":{"auth":"b3RoZXI6b3RoZXI="},` +
+	`"%s/fusion":{"auth":"dXNlcjpwYXNz"}}}`
+
+// newTestRegistry serves a single artifact with the layer as fusion/manifests:v1 and by digest. It asks for
+// a bearer token that is only handed out for user:pass. It returns the image reference by digest and the
+// CA bundle of the registry
+func newTestRegistry(layer []byte, title string) (*httptest.Server, string, []byte) {
+	descriptor := ociDescriptor{MediaType: "application/vnd.oci.image.layer.v1.tar", Digest: manifestDigest(layer), Size: int64(len(layer))}
+	if title != "" {
+		descriptor.Annotations = map[string]string{ociTitleAnnotation: title}
+	}
+	manifest, err := json.Marshal(ociManifest{MediaType: ociManifestMediaType, Layers: []ociDescriptor{descriptor}})
+	Expect(err).ToNot(HaveOccurred())
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/token" {
+			if user, pass, ok := req.BasicAuth(); !ok || user != "user" || pass != "pass" ||
+				req.URL.Query().Get("scope") != "repository:fusion/manifests:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token":"secret-token"}`))
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer secret-token" {
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="https://%s/token",service="test",scope="repository:fusion/manifests:pull"`, req.Host))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch req.URL.Path {
+		case "/v2/fusion/manifests/manifests/v1", "/v2/fusion/manifests/manifests/" + manifestDigest(manifest):
+			w.Header().Set("Content-Type", ociManifestMediaType)
+			_, _ = w.Write(manifest)
+		case "/v2/fusion/manifests/blobs/" + descriptor.Digest:
+			_, _ = w.Write(layer)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	DeferCleanup(server.Close)
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	image := strings.TrimPrefix(server.URL, "https://") + "/fusion/manifests@" + manifestDigest(manifest)
+	return server, image, caBundle
+}
+
+var _ = Describe("OCI artifacts", func() {
+	ctx := context.Background()
+
+	It("pulls a manifest with a bearer token", func() {
+		server, image, caBundle := newTestRegistry([]byte(testExternalManifest), "install.yaml")
+		dockerConfig := fmt.Sprintf(testRegistryDockerConfig, strings.TrimPrefix(server.URL, "https://"))
+
+		data, err := pullOCIArtifact(ctx, image, []byte(dockerConfig), caBundle)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(testExternalManifest))
+
+		tagged := strings.Split(image, "@")[0] + ":v1"
+		data, err = pullOCIArtifact(ctx, tagged, []byte(dockerConfig), caBundle)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(testExternalManifest))
+	})
+
+	It("resolves a tag to the digest of its manifest", func() {
+		server, image, caBundle := newTestRegistry([]byte(testExternalManifest), "install.yaml")
+		dockerConfig := fmt.Sprintf(testRegistryDockerConfig, strings.TrimPrefix(server.URL, "https://"))
+		name, digest, _ := strings.Cut(image, "@")
+
+		resolved, err := resolveImageDigest(ctx, name+":v1", []byte(dockerConfig), caBundle)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resolved).To(Equal(digest))
+		resolved, err = resolveImageDigest(ctx, image, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resolved).To(Equal(digest))
+	})
+
+	It("fails without the credentials or the CA of the registry", func() {
+		server, image, caBundle := newTestRegistry([]byte(testExternalManifest), "install.yaml")
+		dockerConfig := fmt.Sprintf(testRegistryDockerConfig, strings.TrimPrefix(server.URL, "https://"))
+
+		_, err := pullOCIArtifact(ctx, image, nil, caBundle)
+		Expect(err).To(MatchError(ContainSubstring("401")))
+		_, err = pullOCIArtifact(ctx, image, []byte(dockerConfig), nil)
+		Expect(err).To(MatchError(ContainSubstring("certificate")))
+	})
+
+	It("rejects a manifest that does not match the digest of the reference", func() {
+		_, image, caBundle := newTestRegistry([]byte(testExternalManifest), "install.yaml")
+		image = strings.Split(image, "@")[0] + "@" + manifestDigest([]byte("something else"))
+		_, err := pullOCIArtifact(ctx, image, nil, caBundle)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("parses image references", func() {
+		ref, err := parseImageReference("mirror.example.com:5000/fusion/manifests:5.2.3")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*ref).To(Equal(imageReference{Registry: "mirror.example.com:5000", Repository: "fusion/manifests", Reference: "5.2.3"}))
+
+		ref, err = parseImageReference("mirror.example.com/manifests")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ref.Reference).To(Equal("latest"))
+
+		_, err = parseImageReference("fusion/manifests:v1")
+		Expect(err).To(HaveOccurred())
+		_, err = parseImageReference("mirror.example.com/manifests@md5:abc")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("uses the most specific credentials", func() {
+		dockerConfig := fmt.Sprintf(testRegistryDockerConfig, "registry.example.com")
+		user, pass, err := registryCredentials([]byte(dockerConfig), "registry.example.com", "fusion/manifests")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(user + ":" + pass).To(Equal("user:pass"))
+
+		user, _, err = registryCredentials([]byte(dockerConfig), "registry.example.com", "other/manifests")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(user).To(Equal("other"))
+
+		user, _, err = registryCredentials([]byte(dockerConfig), "quay.io", "fusion/manifests")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(user).To(BeEmpty())
+	})
+
+	It("parses authentication challenges", func() {
+		scheme, params := parseAuthChallenge(`Bearer realm="https://auth.example.com/token",service="registry",scope="repository:a,b:pull"`)
+		Expect(scheme).To(Equal("Bearer"))
+		Expect(params).To(Equal(map[string]string{
+			"realm": "https://auth.example.com/token", "service": "registry", "scope": "repository:a,b:pull",
+		}))
+	})
+
+	It("unpacks compressed tar layers", func() {
+		var archive bytes.Buffer
+		gz := gzip.NewWriter(&archive)
+		tw := tar.NewWriter(gz)
+		for name, content := range map[string]string{"README": "readme", "install.yaml": testExternalManifest} {
+			Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+			_, err := tw.Write([]byte(content))
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(tw.Close()).To(Succeed())
+		Expect(gz.Close()).To(Succeed())
+
+		data, err := unpackLayer(archive.Bytes())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(testExternalManifest))
+	})
+})
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+//+kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=fusionaccessoperatorconfigs,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=fusionaccessoperatorconfigs/status,verbs=get;update;patch
+
+// FusionAccessOperatorConfigReconciler reports the settings in use in the status of the
+// FusionAccessOperatorConfig. The other reconcilers read the object themselves, so that they always
+// use its latest spec
+type FusionAccessOperatorConfigReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// EnsureOperatorConfig creates the FusionAccessOperatorConfig singleton with an empty spec when it is
+// missing, so that administrators have an object to edit and to read the effective settings from
+func EnsureOperatorConfig(ctx context.Context, cl client.Client) error {
+	config := &fusionv1alpha1.FusionAccessOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: fusionv1alpha1.FusionAccessOperatorConfigName},
+	}
+	err := cl.Create(ctx, config)
+	if kerrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err == nil {
+		log.Log.Info("Created FusionAccessOperatorConfig " + config.Name)
+	}
+	return err
+}
+
+// Reconcile loads the FusionAccessOperatorConfig and writes the effective settings to its status
+func (r *FusionAccessOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if req.Name != fusionv1alpha1.FusionAccessOperatorConfigName {
+		return ctrl.Result{}, nil
+	}
+	config := &fusionv1alpha1.FusionAccessOperatorConfig{}
+	if err := r.Get(ctx, req.NamespacedName, config); err != nil {
+		if kerrors.IsNotFound(err) {
+			common.SetOperatorSettings(fusionv1alpha1.OperatorSettings{})
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	common.SetOperatorSettings(config.Spec)
+
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status := fusionv1alpha1.FusionAccessOperatorConfigStatus{
+		ObservedGeneration:  config.Generation,
+		Effective:           common.EffectiveOperatorSettings(),
+		DeploymentNamespace: ns,
+		WebhooksEnabled:     utils.WebhooksEnabled(),
+	}
+	if reflect.DeepEqual(config.Status, status) {
+		return ctrl.Result{}, nil
+	}
+	config.Status = status
+	log.Log.Info("Updating the effective operator settings", "settings", status.Effective)
+	return ctrl.Result{}, r.Status().Update(ctx, config)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FusionAccessOperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&fusionv1alpha1.FusionAccessOperatorConfig{}).
+		Complete(r)
+}
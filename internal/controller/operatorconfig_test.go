@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+)
+
+var _ = Describe("FusionAccessOperatorConfig", func() {
+	var (
+		ctx = context.Background()
+		r   *FusionAccessOperatorConfigReconciler
+		key = types.NamespacedName{Name: fusionv1alpha1.FusionAccessOperatorConfigName}
+	)
+
+	setup := func(objs ...client.Object) {
+		fr := newFakeReconciler(objs, withStatusSubresource(&fusionv1alpha1.FusionAccessOperatorConfig{}))
+		r = &FusionAccessOperatorConfigReconciler{Client: fr.Client, Scheme: fr.Scheme}
+	}
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", "ibm-fusion-access-operator")
+		GinkgoT().Setenv(common.DeviceFinderImageEnv, "quay.io/example/devicefinder:env")
+		GinkgoT().Setenv(common.PriorityClassNameEnv, "")
+		DeferCleanup(common.SetOperatorSettings, fusionv1alpha1.OperatorSettings{})
+	})
+
+	It("creates an empty singleton once", func() {
+		setup()
+		Expect(EnsureOperatorConfig(ctx, r.Client)).To(Succeed())
+		Expect(EnsureOperatorConfig(ctx, r.Client)).To(Succeed())
+
+		config := &fusionv1alpha1.FusionAccessOperatorConfig{}
+		Expect(r.Get(ctx, key, config)).To(Succeed())
+		Expect(config.Spec).To(Equal(fusionv1alpha1.OperatorSettings{}))
+	})
+
+	It("reports the environment and the defaults when the spec is empty", func() {
+		setup(&fusionv1alpha1.FusionAccessOperatorConfig{ObjectMeta: metav1.ObjectMeta{Name: key.Name}})
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+
+		config := &fusionv1alpha1.FusionAccessOperatorConfig{}
+		Expect(r.Get(ctx, key, config)).To(Succeed())
+		Expect(config.Status.Effective.DeviceFinderImage).To(Equal("quay.io/example/devicefinder:env"))
+		Expect(config.Status.Effective.PriorityClassName).To(BeEmpty())
+		Expect(config.Status.Effective.IBMRegistry).To(Equal("cp.icr.io"))
+		Expect(config.Status.Effective.IBMRegistryUser).To(Equal("cp"))
+		Expect(config.Status.Effective.EntitlementNamespaces).To(ContainElement("ibm-spectrum-scale-csi"))
+		Expect(config.Status.DeploymentNamespace).To(Equal("ibm-fusion-access-operator"))
+		Expect(config.Status.WebhooksEnabled).To(BeTrue())
+	})
+
+	It("prefers the spec over the environment", func() {
+		setup(&fusionv1alpha1.FusionAccessOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Generation: 3},
+			Spec: fusionv1alpha1.OperatorSettings{
+				DeviceFinderImage:     "mirror.example.com/devicefinder:spec",
+				PriorityClassName:     "system-node-critical",
+				IBMRegistry:           "registry.example.com:5000",
+				IBMRegistryUser:       "scale",
+				EntitlementNamespaces: []string{"ibm-spectrum-scale"},
+			},
+		})
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+
+		config := &fusionv1alpha1.FusionAccessOperatorConfig{}
+		Expect(r.Get(ctx, key, config)).To(Succeed())
+		Expect(config.Status.ObservedGeneration).To(Equal(int64(3)))
+		Expect(config.Status.Effective.DeviceFinderImage).To(Equal("mirror.example.com/devicefinder:spec"))
+		Expect(common.GetPriorityClassName()).To(Equal("system-node-critical"))
+		Expect(IbmEntitlementSecrets("ibm-fusion-access-operator")).To(ConsistOf(
+			"ibm-fusion-access-operator", "ibm-spectrum-scale",
+		))
+
+		secretJSON, err := getDockerConfigSecretJSON([]byte("key"))
+		Expect(err).ToNot(HaveOccurred())
+		auths := map[string]map[string]map[string]string{}
+		Expect(json.Unmarshal(secretJSON, &auths)).To(Succeed())
+		Expect(auths["auths"]).To(HaveKey("registry.example.com:5000"))
+		Expect(auths["auths"]["registry.example.com:5000"]["auth"]).
+			To(Equal(base64.StdEncoding.EncodeToString([]byte("scale:key"))))
+	})
+
+	It("falls back to the environment when the singleton is deleted", func() {
+		common.SetOperatorSettings(fusionv1alpha1.OperatorSettings{DeviceFinderImage: "stale"})
+		setup()
+		Expect(common.LoadOperatorConfig(ctx, r.Client)).To(Succeed())
+		Expect(common.GetDeviceFinderImage()).To(Equal("quay.io/example/devicefinder:env"))
+	})
+})
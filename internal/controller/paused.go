@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+// reconcilePaused only refreshes the status while reconciliation is paused. Nothing is applied, the
+// secrets, the kernel module and the console plugin are left as they are
+func (r *FusionAccessReconciler) reconcilePaused(ctx context.Context, ns string, fusionaccess *fusionv1alpha1.FusionAccess) (ctrl.Result, error) {
+	log.Log.Info("Reconciliation is paused, only refreshing the status", "annotation", fusionv1alpha1.PausedAnnotation)
+	setCondition(fusionaccess, fusionv1alpha1.ConditionPaused, v1.ConditionTrue, fusionv1alpha1.ReasonReconcilePaused,
+		"Reconciliation is paused, remove the "+fusionv1alpha1.PausedAnnotation+" annotation to resume")
+
+	operatorAvailable, err := r.setStorageScaleOperatorCondition(ctx, fusionaccess)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileCapacity(ctx, ns, fusionaccess); err != nil {
+		log.Log.Error(err, "Error computing the device capacity")
+	}
+	if err := r.reconcileSupported(ctx, fusionaccess); err != nil {
+		log.Log.Error(err, "Error checking if the Storage Scale version is supported")
+	}
+	if err := r.reconcileUpgradeable(ctx, fusionaccess); err != nil {
+		log.Log.Error(err, "Error checking if OpenShift can be upgraded")
+	}
+	if err := r.updateStatus(ctx, fusionaccess); err != nil {
+		return ctrl.Result{}, err
+	}
+	if !operatorAvailable {
+		return waitForComponents, nil
+	}
+	return refreshCapacity, nil
+}
+
+// resumeReconcile prepares a full reconcile after the pause ended. The manifest is applied again even
+// if it did not change, as the IBM resources may have been changed while paused
+func resumeReconcile(fusionaccess *fusionv1alpha1.FusionAccess) {
+	if !meta.IsStatusConditionTrue(fusionaccess.Status.Conditions, fusionv1alpha1.ConditionPaused) {
+		return
+	}
+	log.Log.Info("Reconciliation was resumed, running a full reconcile")
+	if fusionaccess.Status.Manifest != nil {
+		fusionaccess.Status.Manifest.Hash = ""
+	}
+	setCondition(fusionaccess, fusionv1alpha1.ConditionPaused, v1.ConditionFalse, fusionv1alpha1.ReasonReconcileResumed,
+		"Reconciliation was resumed")
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	consolev1 "github.com/openshift/api/console/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/console"
+)
+
+var _ = Describe("Paused reconciliation", func() {
+	var (
+		ctx          = context.Background()
+		cl           client.Client
+		r            *FusionAccessReconciler
+		fusionaccess *fusionv1alpha.FusionAccess
+	)
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", "ibm-fusion-access-operator")
+		fusionaccess = &fusionv1alpha.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        resourceName,
+				Namespace:   "ibm-fusion-access-operator",
+				Annotations: map[string]string{fusionv1alpha.PausedAnnotation: "true"},
+				Finalizers:  []string{storageScaleFinalizer},
+			},
+			Spec: fusionv1alpha.FusionAccessSpec{StorageScaleVersion: "v5.2.3.1.dev3"},
+		}
+	})
+
+	build := func() {
+		r = newFakeReconciler([]client.Object{fusionaccess}, withStatusSubresource(&fusionv1alpha.FusionAccess{}))
+		r.fullClient = kubeclient.NewSimpleClientset()
+		cl = r.Client
+	}
+
+	It("only reports status while paused", func() {
+		build()
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(fusionaccess)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(waitForComponents))
+
+		updated := &fusionv1alpha.FusionAccess{}
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(fusionaccess), updated)).To(Succeed())
+		cond := meta.FindStatusCondition(updated.Status.Conditions, fusionv1alpha.ConditionPaused)
+		Expect(cond).ToNot(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonReconcilePaused))
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, fusionv1alpha.ConditionStorageScaleOperator)).ToNot(BeNil())
+		Expect(updated.Status.Manifest).To(BeNil())
+
+		// Nothing was applied, e.g. the console plugin was not created
+		err = cl.Get(ctx, client.ObjectKey{Name: console.PluginName}, &consolev1.ConsolePlugin{})
+		Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("holds the teardown while paused", func() {
+		now := metav1.Now()
+		fusionaccess.DeletionTimestamp = &now
+		build()
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(fusionaccess)})
+		Expect(err).ToNot(HaveOccurred())
+		updated := &fusionv1alpha.FusionAccess{}
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(fusionaccess), updated)).To(Succeed())
+		Expect(updated.Finalizers).To(ContainElement(storageScaleFinalizer))
+	})
+
+	It("forces the manifest to be applied again on resume", func() {
+		fusionaccess.Status.Manifest = &fusionv1alpha.ManifestStatus{Hash: "abc"}
+		setCondition(fusionaccess, fusionv1alpha.ConditionPaused, metav1.ConditionTrue, fusionv1alpha.ReasonReconcilePaused, "")
+		delete(fusionaccess.Annotations, fusionv1alpha.PausedAnnotation)
+
+		resumeReconcile(fusionaccess)
+		Expect(fusionaccess.Status.Manifest.Hash).To(BeEmpty())
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionPaused)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonReconcileResumed))
+
+		// Nothing to do when reconciliation was not paused
+		fusionaccess.Status.Manifest.Hash = "def"
+		resumeReconcile(fusionaccess)
+		Expect(fusionaccess.Status.Manifest.Hash).To(Equal("def"))
+	})
+})
@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/manifestival/manifestival"
+	configv1 "github.com/openshift/api/config/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+const (
+	// trustedCABundleConfigMap is added to the namespaces of the IBM Deployments
+	trustedCABundleConfigMap = utils.TrustedCABundleConfigMap
+	// trustedCABundleMountPath is where the system CA bundle of RHEL based images is read from
+	trustedCABundleMountPath = "/etc/pki/ca-trust/extracted/pem"
+	// proxyHashAnnotation is set on the pod template of the IBM Deployments so that they are rolled out
+	// again when the proxy or the trusted CA bundle changes
+	proxyHashAnnotation = "fusion.storage.openshift.io/proxy-hash"
+)
+
+// injectClusterProxy adds the cluster-wide proxy and the trusted CA bundle to the Deployments of the
+// manifest. The manifest is returned unchanged when the cluster does not use a proxy. The bundle injected
+// into the operator namespace, which the kernel module build uses as well, is part of the proxy hash
+func (r *FusionAccessReconciler) injectClusterProxy(ctx context.Context, installManifest manifestival.Manifest) (manifestival.Manifest, error) {
+	proxy, err := utils.GetClusterProxy(ctx, r.Client)
+	if err != nil || proxy == nil {
+		return installManifest, err
+	}
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return installManifest, err
+	}
+	caBundle, err := utils.GetTrustedCABundle(ctx, r.Client, ns)
+	if err != nil {
+		return installManifest, err
+	}
+
+	namespaces := []string{}
+	injected, err := installManifest.Transform(func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "Deployment" {
+			return nil
+		}
+		if !slices.Contains(namespaces, u.GetNamespace()) {
+			namespaces = append(namespaces, u.GetNamespace())
+		}
+		return injectProxy(u, proxy, caBundle)
+	})
+	if err != nil {
+		return installManifest, err
+	}
+
+	// The ConfigMaps go last, after the namespaces they are created in
+	bundles := make([]unstructured.Unstructured, 0, len(namespaces))
+	for _, ns := range namespaces {
+		cm := unstructured.Unstructured{}
+		cm.SetAPIVersion("v1")
+		cm.SetKind("ConfigMap")
+		cm.SetName(trustedCABundleConfigMap)
+		cm.SetNamespace(ns)
+		cm.SetLabels(map[string]string{utils.InjectTrustedCABundleLabel: "true"})
+		bundles = append(bundles, cm)
+	}
+	bundleManifest, err := manifestival.ManifestFrom(manifestival.Slice(bundles), manifestival.UseClient(installManifest.Client))
+	if err != nil {
+		return installManifest, err
+	}
+	log.Log.Info("Injecting the cluster proxy into the IBM Deployments", "httpProxy", proxy.HTTPProxy,
+		"httpsProxy", proxy.HTTPSProxy, "namespaces", namespaces)
+	return injected.Append(bundleManifest), nil
+}
+
+// injectProxy sets the proxy environment variables and mounts the trusted CA bundle in every container of
+// the Deployment. It is applied as a strategic merge patch, so that variables and volumes of the same name
+// are replaced rather than duplicated
+func injectProxy(u *unstructured.Unstructured, proxy *configv1.ProxyStatus, caBundle string) error {
+	env := []map[string]any{}
+	for _, v := range []struct{ name, value string }{
+		{"HTTP_PROXY", proxy.HTTPProxy},
+		{"HTTPS_PROXY", proxy.HTTPSProxy},
+		{"NO_PROXY", proxy.NoProxy},
+	} {
+		if v.value != "" {
+			env = append(env, map[string]any{"name": v.name, "value": v.value})
+		}
+	}
+	mount := map[string]any{"name": trustedCABundleConfigMap, "mountPath": trustedCABundleMountPath, "readOnly": true}
+
+	podSpec := map[string]any{
+		"volumes": []any{map[string]any{
+			"name": trustedCABundleConfigMap,
+			"configMap": map[string]any{
+				"name":  trustedCABundleConfigMap,
+				"items": []any{map[string]any{"key": utils.TrustedCABundleKey, "path": "tls-ca-bundle.pem"}},
+			},
+		}},
+	}
+	for _, field := range []string{"containers", "initContainers"} {
+		list, _, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", field)
+		if err != nil {
+			return fmt.Errorf("invalid %s of %s: %w", field, resourceID(u), err)
+		}
+		containers := []any{}
+		for _, c := range list {
+			if container, ok := c.(map[string]any); ok {
+				containers = append(containers, map[string]any{"name": container["name"], "env": env, "volumeMounts": []any{mount}})
+			}
+		}
+		if len(containers) > 0 {
+			podSpec[field] = containers
+		}
+	}
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{"annotations": map[string]any{proxyHashAnnotation: proxyHash(proxy, caBundle)}},
+				"spec":     podSpec,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	original, err := json.Marshal(u.Object)
+	if err != nil {
+		return err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, patch, appsv1.Deployment{})
+	if err != nil {
+		return fmt.Errorf("failed to inject the proxy into %s: %w", resourceID(u), err)
+	}
+	object := map[string]any{}
+	if err := json.Unmarshal(patched, &object); err != nil {
+		return err
+	}
+	u.Object = object
+	return nil
+}
+
+// proxyHash identifies the proxy settings and the trusted CA bundle
+func proxyHash(proxy *configv1.ProxyStatus, caBundle string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s", proxy.HTTPProxy, proxy.HTTPSProxy, proxy.NoProxy, caBundle)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+
+	"github.com/manifestival/manifestival"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+// testProxyManifest has a Deployment that already sets one of the proxy variables
+const testProxyManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: ibm-spectrum-scale-operator
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ibm-spectrum-scale-controller-manager
+  namespace: ibm-spectrum-scale-operator
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: quay.io/example/init:1.0
+      containers:
+      - name: manager
+        image: quay.io/example/manager:1.0
+        env:
+        - name: NO_PROXY
+          value: stale
+        - name: WATCH_NAMESPACE
+          value: ""
+`
+
+var _ = Describe("Cluster proxy", func() {
+	const ns = "ibm-fusion-access-operator"
+	var (
+		ctx             = context.Background()
+		r               *FusionAccessReconciler
+		installManifest manifestival.Manifest
+	)
+
+	setup := func(objs ...client.Object) {
+		r = newFakeReconciler(objs)
+	}
+	newProxy := func(httpProxy, noProxy string) *configv1.Proxy {
+		return &configv1.Proxy{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Status:     configv1.ProxyStatus{HTTPProxy: httpProxy, HTTPSProxy: httpProxy, NoProxy: noProxy},
+		}
+	}
+	deployment := func(m manifestival.Manifest) *appsv1.Deployment {
+		filtered := m.Filter(manifestival.ByKind("Deployment"))
+		Expect(filtered.Resources()).To(HaveLen(1))
+		d := &appsv1.Deployment{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(filtered.Resources()[0].Object, d)).To(Succeed())
+		return d
+	}
+
+	newCABundle := func(bundle string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: utils.TrustedCABundleConfigMap, Namespace: ns},
+			Data:       map[string]string{utils.TrustedCABundleKey: bundle},
+		}
+	}
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", ns)
+		path := GinkgoT().TempDir() + "/install.yaml"
+		Expect(os.WriteFile(path, []byte(testProxyManifest), 0o600)).To(Succeed())
+		var err error
+		installManifest, err = manifestival.NewManifest(path)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("leaves the manifest alone without a proxy", func() {
+		setup(newProxy("", ""))
+		injected, err := r.injectClusterProxy(ctx, installManifest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(injected.Resources()).To(Equal(installManifest.Resources()))
+	})
+
+	It("injects the proxy and the trusted CA bundle into the Deployments", func() {
+		setup(newProxy("http://proxy.example.com:3128", ".cluster.local,10.0.0.0/16"))
+		injected, err := r.injectClusterProxy(ctx, installManifest)
+		Expect(err).ToNot(HaveOccurred())
+
+		d := deployment(injected)
+		manager := d.Spec.Template.Spec.Containers[0]
+		Expect(manager.Env).To(ConsistOf(
+			corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy.example.com:3128"},
+			corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"},
+			corev1.EnvVar{Name: "NO_PROXY", Value: ".cluster.local,10.0.0.0/16"},
+			corev1.EnvVar{Name: "WATCH_NAMESPACE"},
+		))
+		Expect(manager.VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+			Name: trustedCABundleConfigMap, MountPath: trustedCABundleMountPath, ReadOnly: true,
+		}))
+		Expect(d.Spec.Template.Spec.InitContainers[0].Env).To(HaveLen(3))
+		Expect(d.Spec.Template.Spec.Volumes).To(HaveLen(1))
+		Expect(d.Spec.Template.Spec.Volumes[0].ConfigMap.Name).To(Equal(trustedCABundleConfigMap))
+		Expect(d.Spec.Template.Annotations).To(HaveKey(proxyHashAnnotation))
+
+		bundles := injected.Filter(manifestival.ByKind("ConfigMap")).Resources()
+		Expect(bundles).To(HaveLen(1))
+		Expect(bundles[0].GetNamespace()).To(Equal("ibm-spectrum-scale-operator"))
+		Expect(bundles[0].GetLabels()).To(HaveKeyWithValue(utils.InjectTrustedCABundleLabel, "true"))
+		Expect(injected.Resources()[len(injected.Resources())-1].GetKind()).To(Equal("ConfigMap"))
+	})
+
+	It("rolls the Deployments out again when the proxy changes", func() {
+		setup(newProxy("http://proxy.example.com:3128", ""))
+		before, err := r.injectClusterProxy(ctx, installManifest)
+		Expect(err).ToNot(HaveOccurred())
+		setup(newProxy("http://other-proxy.example.com:3128", ""))
+		after, err := r.injectClusterProxy(ctx, installManifest)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(deployment(after).Spec.Template.Annotations[proxyHashAnnotation]).
+			ToNot(Equal(deployment(before).Spec.Template.Annotations[proxyHashAnnotation]))
+		beforeHash, err := manifestHash(before)
+		Expect(err).ToNot(HaveOccurred())
+		afterHash, err := manifestHash(after)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(afterHash).ToNot(Equal(beforeHash))
+	})
+
+	It("rolls the Deployments out again when the trusted CA bundle changes", func() {
+		proxy := newProxy("http://proxy.example.com:3128", "")
+		setup(proxy, newCABundle("first CA"))
+		before, err := r.injectClusterProxy(ctx, installManifest)
+		Expect(err).ToNot(HaveOccurred())
+		setup(proxy, newCABundle("rotated CA"))
+		after, err := r.injectClusterProxy(ctx, installManifest)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(deployment(after).Spec.Template.Annotations[proxyHashAnnotation]).
+			ToNot(Equal(deployment(before).Spec.Template.Annotations[proxyHashAnnotation]))
+	})
+})
@@ -0,0 +1,305 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/manifestival/manifestival"
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/console"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/kernelmodule"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+const (
+	// requiredImagesConfigMap lists the images the installation needs, so that they can be mirrored
+	// before a disconnected install. It is owned by the FusionAccess object
+	requiredImagesConfigMap = "fusionaccess-required-images"
+	// requiredImagesKey holds the images, one per line
+	requiredImagesKey = "images.txt"
+	// imageSetConfigurationKey holds the images as an oc-mirror ImageSetConfiguration
+	imageSetConfigurationKey = "imageset-configuration.yaml"
+)
+
+// imageMirrors are the mirrors configured on the cluster, by source repository
+type imageMirrors struct {
+	// digest mirrors come from the ImageDigestMirrorSets and ImageContentSourcePolicies
+	digest map[string][]string
+	// tag mirrors come from the ImageTagMirrorSets
+	tag map[string][]string
+}
+
+// empty is true when the cluster does not mirror any image, it is then considered connected
+func (m *imageMirrors) empty() bool {
+	return len(m.digest) == 0 && len(m.tag) == 0
+}
+
+// mirrorsFor returns where the image is pulled from instead, or nothing when no mirror covers it.
+// As in the container runtime, the most specific source wins and a digest is only looked up in the
+// digest mirrors and a tag in the tag mirrors
+func (m *imageMirrors) mirrorsFor(image string) []string {
+	name, suffix := image, ""
+	if i := strings.Index(image, "@"); i >= 0 {
+		name, suffix = image[:i], image[i:]
+	} else if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, suffix = image[:i], image[i:]
+	}
+	mirrors := m.tag
+	if strings.HasPrefix(suffix, "@") {
+		mirrors = m.digest
+	}
+
+	best := ""
+	for source := range mirrors {
+		if sourceMatches(source, name) && len(source) > len(best) {
+			best = source
+		}
+	}
+	if best == "" {
+		return nil
+	}
+	var mirrored []string
+	for _, mirror := range mirrors[best] {
+		if strings.HasPrefix(best, "*.") {
+			// A wildcard source only replaces the registry
+			_, repository, _ := strings.Cut(name, "/")
+			mirrored = append(mirrored, mirror+"/"+repository+suffix)
+		} else {
+			mirrored = append(mirrored, mirror+strings.TrimPrefix(name, best)+suffix)
+		}
+	}
+	return mirrored
+}
+
+// sourceMatches is true when the repository is the source, in its namespace, or on a registry of a
+// "*.example.com" wildcard source
+func sourceMatches(source, repository string) bool {
+	if domain, ok := strings.CutPrefix(source, "*."); ok {
+		registry, _, _ := strings.Cut(repository, "/")
+		return strings.HasSuffix(registry, "."+domain)
+	}
+	return repository == source || strings.HasPrefix(repository, source+"/")
+}
+
+// getImageMirrors reads the mirror sets of the cluster. An API that does not exist on the cluster has no mirrors
+func (r *FusionAccessReconciler) getImageMirrors(ctx context.Context) (*imageMirrors, error) {
+	mirrors := &imageMirrors{digest: map[string][]string{}, tag: map[string][]string{}}
+	add := func(into map[string][]string, source string, sourceMirrors []string) {
+		for _, mirror := range sourceMirrors {
+			if !slices.Contains(into[source], mirror) {
+				into[source] = append(into[source], mirror)
+			}
+		}
+	}
+
+	idms := &configv1.ImageDigestMirrorSetList{}
+	if err := r.List(ctx, idms); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list the ImageDigestMirrorSets: %w", err)
+	}
+	for _, set := range idms.Items {
+		for _, m := range set.Spec.ImageDigestMirrors {
+			add(mirrors.digest, m.Source, imageMirrorNames(m.Mirrors))
+		}
+	}
+	itms := &configv1.ImageTagMirrorSetList{}
+	if err := r.List(ctx, itms); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list the ImageTagMirrorSets: %w", err)
+	}
+	for _, set := range itms.Items {
+		for _, m := range set.Spec.ImageTagMirrors {
+			add(mirrors.tag, m.Source, imageMirrorNames(m.Mirrors))
+		}
+	}
+	icsp := &operatorv1alpha1.ImageContentSourcePolicyList{}
+	if err := r.List(ctx, icsp); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list the ImageContentSourcePolicies: %w", err)
+	}
+	for _, policy := range icsp.Items {
+		for _, m := range policy.Spec.RepositoryDigestMirrors {
+			add(mirrors.digest, m.Source, m.Mirrors)
+		}
+	}
+	return mirrors, nil
+}
+
+func imageMirrorNames(mirrors []configv1.ImageMirror) []string {
+	names := make([]string, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		names = append(names, string(mirror))
+	}
+	return names
+}
+
+// reconcileRequiredImages publishes the images the installation needs and, when the cluster mirrors
+// images, checks that every one of them is covered by a mirror
+func (r *FusionAccessReconciler) reconcileRequiredImages(
+	ctx context.Context,
+	ns string,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	installManifest manifestival.Manifest,
+) error {
+	images, err := r.requiredImages(ctx, ns, installManifest)
+	if err != nil {
+		return err
+	}
+	if err := r.writeRequiredImages(ctx, fusionaccess, images); err != nil {
+		return err
+	}
+
+	mirrors, err := r.getImageMirrors(ctx)
+	if err != nil {
+		return err
+	}
+	if mirrors.empty() {
+		setCondition(fusionaccess, fusionv1alpha1.ConditionImageMirrors, v1.ConditionTrue, fusionv1alpha1.ReasonNotApplicable,
+			fmt.Sprintf("No image mirrors are configured, the %d required images are listed in ConfigMap %s",
+				len(images), requiredImagesConfigMap))
+		return nil
+	}
+	var missing []string
+	for _, image := range images {
+		if len(mirrors.mirrorsFor(image)) == 0 {
+			missing = append(missing, image)
+		}
+	}
+	if len(missing) > 0 {
+		setCondition(fusionaccess, fusionv1alpha1.ConditionImageMirrors, v1.ConditionFalse, fusionv1alpha1.ReasonImagesNotMirrored,
+			fmt.Sprintf("%d of the %d required images are not covered by an image mirror set: %s",
+				len(missing), len(images), strings.Join(missing, ", ")))
+		return nil
+	}
+	setCondition(fusionaccess, fusionv1alpha1.ConditionImageMirrors, v1.ConditionTrue, fusionv1alpha1.ReasonImagesMirrored,
+		fmt.Sprintf("All %d required images are covered by an image mirror set", len(images)))
+	return nil
+}
+
+// requiredImages returns the sorted images of the install manifest, of the IBM operator configuration,
+// of the device discovery, of the console plugin and the base image of the kernel module
+func (r *FusionAccessReconciler) requiredImages(ctx context.Context, ns string, installManifest manifestival.Manifest) ([]string, error) {
+	images := map[string]bool{
+		common.GetDeviceFinderImage():  true,
+		common.GetKubeRBACProxyImage(): true,
+		kernelmodule.BaseImage:         true,
+	}
+	for _, resource := range installManifest.Resources() {
+		collectImages(resource.Object, false, images)
+		if resource.GetKind() != "ConfigMap" || resource.GetName() != "ibm-spectrum-scale-manager-config" {
+			continue
+		}
+		embedded, _, _ := unstructured.NestedString(resource.Object, "data", "controller_manager_config.yaml")
+		var config utils.ControllerManagerConfig
+		if err := yaml.Unmarshal([]byte(embedded), &config); err != nil {
+			return nil, fmt.Errorf("failed to parse the IBM operator configuration: %w", err)
+		}
+		for _, image := range config.Images {
+			images[image] = true
+		}
+	}
+
+	plugin := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: ns, Name: console.ServiceName}, plugin)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get the console plugin deployment: %w", err)
+	}
+	for _, container := range plugin.Spec.Template.Spec.Containers {
+		images[container.Image] = true
+	}
+
+	sorted := make([]string, 0, len(images))
+	for image := range images {
+		if image != "" {
+			sorted = append(sorted, image)
+		}
+	}
+	slices.Sort(sorted)
+	return sorted, nil
+}
+
+// collectImages walks an object of the manifest for the "image" fields of its containers and for the
+// environment variables that hand images to the IBM operators, like the CSI sidecars
+func collectImages(value any, isEnvValue bool, images map[string]bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if s, ok := field.(string); ok && (key == "image" || (isEnvValue && key == "value" && looksLikeImage(s))) {
+				images[s] = true
+				continue
+			}
+			collectImages(field, key == "env", images)
+		}
+	case []any:
+		for _, item := range v {
+			collectImages(item, isEnvValue, images)
+		}
+	}
+}
+
+// looksLikeImage is true for a value that is a registry/repository with a tag or a digest
+func looksLikeImage(value string) bool {
+	if strings.Contains(value, "://") || strings.ContainsAny(value, " \t\n") {
+		return false
+	}
+	if _, err := parseImageReference(value); err != nil {
+		return false
+	}
+	return strings.Contains(value, "@") || strings.LastIndex(value, ":") > strings.LastIndex(value, "/")
+}
+
+// writeRequiredImages stores the images as a plain list and as an oc-mirror ImageSetConfiguration
+func (r *FusionAccessReconciler) writeRequiredImages(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, images []string) error {
+	var imageSet strings.Builder
+	imageSet.WriteString("kind: ImageSetConfiguration\napiVersion: mirror.openshift.io/v2alpha1\nmirror:\n  additionalImages:\n")
+	for _, image := range images {
+		fmt.Fprintf(&imageSet, "  - name: %s\n", image)
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      requiredImagesConfigMap,
+			Namespace: fusionaccess.Namespace,
+		},
+		Data: map[string]string{
+			requiredImagesKey:        strings.Join(images, "\n") + "\n",
+			imageSetConfigurationKey: imageSet.String(),
+		},
+	}
+	if err := controllerutil.SetControllerReference(fusionaccess, cm, r.Scheme); err != nil {
+		return err
+	}
+	return kubeutils.CreateOrUpdateResource(ctx, r.Client, cm, func(existing, desired *corev1.ConfigMap) error {
+		existing.Data = desired.Data
+		existing.OwnerReferences = desired.OwnerReferences
+		return nil
+	})
+}
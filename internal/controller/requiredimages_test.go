@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/manifestival/manifestival"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/console"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/kernelmodule"
+)
+
+// testImagesManifest has images in a container, in the environment of a container and in the IBM
+// operator configuration
+const testImagesManifest = testExternalManifest + `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ibm-spectrum-scale-csi-operator
+  namespace: ibm-spectrum-scale-csi
+spec:
+  template:
+    spec:
+      containers:
+      - name: operator
+        image: quay.io/example/csi-operator:5.2.3
+        env:
+        - name: CSI_SNAPSHOTTER_IMAGE
+          value: quay.io/example/csi-snapshotter@sha256:def456
+        - name: WATCH_NAMESPACE
+          value: ibm-spectrum-scale-csi
+        - name: ENDPOINT
+          value: https://quay.io/example
+`
+
+var _ = Describe("Required images", func() {
+	const ns = "ibm-fusion-access-operator"
+	var (
+		ctx             = context.Background()
+		r               *FusionAccessReconciler
+		fusionaccess    *fusionv1alpha.FusionAccess
+		installManifest manifestival.Manifest
+	)
+
+	setup := func(objs ...client.Object) {
+		r = newFakeReconciler(append(objs, fusionaccess), withStatusSubresource(&fusionv1alpha.FusionAccess{}))
+	}
+	newDigestMirrorSet := func(source, mirror string) *configv1.ImageDigestMirrorSet {
+		return &configv1.ImageDigestMirrorSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "digest-" + strings.ReplaceAll(source, "/", "-")},
+			Spec: configv1.ImageDigestMirrorSetSpec{ImageDigestMirrors: []configv1.ImageDigestMirrors{{
+				Source:  source,
+				Mirrors: []configv1.ImageMirror{configv1.ImageMirror(mirror)},
+			}}},
+		}
+	}
+	newTagMirrorSet := func(source, mirror string) *configv1.ImageTagMirrorSet {
+		return &configv1.ImageTagMirrorSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "tag-" + strings.ReplaceAll(source, "/", "-")},
+			Spec: configv1.ImageTagMirrorSetSpec{ImageTagMirrors: []configv1.ImageTagMirrors{{
+				Source:  source,
+				Mirrors: []configv1.ImageMirror{configv1.ImageMirror(mirror)},
+			}}},
+		}
+	}
+
+	BeforeEach(func() {
+		fusionaccess = &fusionv1alpha.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: ns},
+		}
+		path := GinkgoT().TempDir() + "/install.yaml"
+		Expect(os.WriteFile(path, []byte(testImagesManifest), 0o600)).To(Succeed())
+		var err error
+		installManifest, err = manifestival.NewManifest(path)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("collects the images of the manifest, the IBM configuration and the operator", func() {
+		setup(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: console.ServiceName, Namespace: ns},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "plugin", Image: "quay.io/example/console-plugin:1.0"}},
+			}}},
+		})
+
+		images, err := r.requiredImages(ctx, ns, installManifest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(images).To(ConsistOf(
+			"quay.io/example/core-init@sha256:abc123",
+			"quay.io/example/csi-operator:5.2.3",
+			"quay.io/example/csi-snapshotter@sha256:def456",
+			"quay.io/example/console-plugin:1.0",
+			common.GetDeviceFinderImage(),
+			common.GetKubeRBACProxyImage(),
+			kernelmodule.BaseImage,
+		))
+		Expect(slices.IsSorted(images)).To(BeTrue())
+	})
+
+	It("publishes the images in a ConfigMap", func() {
+		setup()
+		Expect(r.reconcileRequiredImages(ctx, ns, fusionaccess, installManifest)).To(Succeed())
+
+		cm := &corev1.ConfigMap{}
+		Expect(r.Get(ctx, types.NamespacedName{Namespace: ns, Name: requiredImagesConfigMap}, cm)).To(Succeed())
+		Expect(cm.Data[requiredImagesKey]).To(ContainSubstring("quay.io/example/csi-operator:5.2.3\n"))
+		Expect(cm.Data[imageSetConfigurationKey]).To(HavePrefix("kind: ImageSetConfiguration\n"))
+		Expect(cm.Data[imageSetConfigurationKey]).To(ContainSubstring("  - name: quay.io/example/core-init@sha256:abc123\n"))
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionImageMirrors)
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonNotApplicable))
+	})
+
+	It("reports the images that are not mirrored", func() {
+		setup(newDigestMirrorSet("quay.io/example", "mirror.example.com/example"))
+		Expect(r.reconcileRequiredImages(ctx, ns, fusionaccess, installManifest)).To(Succeed())
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionImageMirrors)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonImagesNotMirrored))
+		Expect(cond.Message).To(ContainSubstring("quay.io/example/csi-operator:5.2.3"))
+		Expect(cond.Message).ToNot(ContainSubstring("quay.io/example/core-init@sha256:abc123"))
+	})
+
+	It("accepts a cluster that mirrors every image", func() {
+		setup(
+			newDigestMirrorSet("quay.io", "mirror.example.com/quay"),
+			newTagMirrorSet("quay.io", "mirror.example.com/quay"),
+			newTagMirrorSet("registry.redhat.io", "mirror.example.com/redhat"),
+		)
+		Expect(r.reconcileRequiredImages(ctx, ns, fusionaccess, installManifest)).To(Succeed())
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionImageMirrors)
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonImagesMirrored))
+	})
+
+	It("rewrites images to their most specific mirror", func() {
+		setup(
+			newDigestMirrorSet("quay.io", "mirror.example.com/quay"),
+			newDigestMirrorSet("quay.io/example/core-init", "mirror.example.com/init"),
+			newTagMirrorSet("*.redhat.io", "mirror.example.com/redhat"),
+			&operatorv1alpha1.ImageContentSourcePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "icsp"},
+				Spec: operatorv1alpha1.ImageContentSourcePolicySpec{
+					RepositoryDigestMirrors: []operatorv1alpha1.RepositoryDigestMirrors{{
+						Source: "quay.io", Mirrors: []string{"backup.example.com/quay"},
+					}},
+				},
+			},
+		)
+		mirrors, err := r.getImageMirrors(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(mirrors.mirrorsFor("quay.io/example/core-init@sha256:abc123")).To(Equal([]string{
+			"mirror.example.com/init@sha256:abc123",
+		}))
+		Expect(mirrors.mirrorsFor("quay.io/example/csi@sha256:def456")).To(ConsistOf(
+			"mirror.example.com/quay/example/csi@sha256:def456",
+			"backup.example.com/quay/example/csi@sha256:def456",
+		))
+		Expect(mirrors.mirrorsFor("registry.redhat.io/ubi9/ubi-minimal")).To(Equal([]string{
+			"mirror.example.com/redhat/ubi9/ubi-minimal",
+		}))
+		// Tags are only mirrored by an ImageTagMirrorSet
+		Expect(mirrors.mirrorsFor("quay.io/example/csi:5.2.3")).To(BeEmpty())
+		// A source only covers its own namespace
+		Expect(mirrors.mirrorsFor("quay.io.example.com/csi@sha256:def456")).To(BeEmpty())
+	})
+})
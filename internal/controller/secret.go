@@ -12,23 +12,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
 )
 
 const FUSIONPULLSECRETNAME = "fusion-pullsecret" //nolint:gosec
 const IBMENTITLEMENTNAME = "ibm-entitlement-key"
-const IBMREGISTRY = "cp.icr.io"
-const IBMREGISTRYUSER = "cp"
 
 // IbmEntitlementSecrets returns the list of namespaces where the entitlement secret should be created
 // plus the namespace of the operator because in that namespace we do the pod pull check
 func IbmEntitlementSecrets(ourNs string) []string {
-	return []string{
-		ourNs,
-		"ibm-spectrum-scale",
-		"ibm-spectrum-scale-dns",
-		"ibm-spectrum-scale-csi",
-		"ibm-spectrum-scale-operator",
-	}
+	return append([]string{ourNs}, common.GetEntitlementNamespaces()...)
 }
 
 func newSecret(name, namespace string, secret map[string][]byte, secretType corev1.SecretType, labels map[string]string) *corev1.Secret {
@@ -72,12 +66,13 @@ func getPullJson() []byte {
 }
 
 func getDockerConfigSecretJSON(secret []byte) ([]byte, error) {
-	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", IBMREGISTRYUSER, secret)))
+	user := common.GetIBMRegistryUser()
+	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", user, secret)))
 	auths := map[string]any{
 		"auths": map[string]any{
-			IBMREGISTRY: map[string]string{
+			common.GetIBMRegistry(): map[string]string{
 				"auth":     auth,
-				"username": IBMREGISTRYUSER,
+				"username": user,
 			},
 		},
 	}
@@ -134,3 +129,17 @@ func updateEntitlementPullSecrets(secret []byte, ctx context.Context, full kuber
 	}
 	return nil
 }
+
+func deleteEntitlementPullSecrets(ctx context.Context, full kubernetes.Interface, ns string) error {
+	for _, destNamespace := range IbmEntitlementSecrets(ns) {
+		err := full.CoreV1().Secrets(destNamespace).Delete(ctx, IBMENTITLEMENTNAME, metav1.DeleteOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		log.Log.Info(fmt.Sprintf("Deleted Secret %s in ns %s", IBMENTITLEMENTNAME, destNamespace))
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+// StorageClusterName is the name of the IBM Storage Scale Cluster, the same the console creates it with
+const StorageClusterName = "ibm-spectrum-scale"
+
+// defaultStorageClusterEdition is the IBM Storage Scale edition used when the spec does not set one
+const defaultStorageClusterEdition = "data-management"
+
+// ibmHealthConditions are the conditions of the IBM Cluster and Filesystem that all need to be True for them
+// to be healthy
+var ibmHealthConditions = []string{"Success", "Healthy"}
+
+// storageClusterFields are the fields of the IBM Cluster replaced with the values from the FusionAccess spec.
+// The daemon cluster profile is merged instead, as the IBM CRD defaults some of its parameters
+var storageClusterFields = [][]string{
+	{"spec", "license"},
+	{"spec", "daemon", "nodeSelector"},
+	{"spec", "pmcollector", "nodeSelector"},
+}
+
+// reconcileStorageCluster creates or updates the IBM Storage Scale Cluster from spec.storageCluster and mirrors
+// its health in the StorageCluster condition. The Cluster is only created once the IBM operator is available,
+// as the IBM webhooks reject it before. The Cluster is cluster-scoped, so it is only labelled with its owner
+func (r *FusionAccessReconciler) reconcileStorageCluster(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	operatorAvailable bool,
+) (ctrl.Result, error) {
+	if fusionaccess.Spec.StorageCluster == nil {
+		setCondition(fusionaccess, fusionv1alpha1.ConditionStorageCluster, v1.ConditionTrue,
+			fusionv1alpha1.ReasonNotApplicable, "No storage cluster is set in the spec")
+		return ctrl.Result{}, nil
+	}
+	if !operatorAvailable {
+		setCondition(fusionaccess, fusionv1alpha1.ConditionStorageCluster, v1.ConditionFalse,
+			fusionv1alpha1.ReasonStorageScaleOperatorUnavailable,
+			"Waiting for the IBM Storage Scale operator before creating the storage cluster")
+		return waitForComponents, nil
+	}
+
+	desired, err := newStorageCluster(fusionaccess.Spec.StorageCluster)
+	if err != nil {
+		return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionStorageCluster, err)
+	}
+	if err := kubeutils.SetOwner(fusionaccess, desired, r.Scheme); err != nil {
+		return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionStorageCluster, err)
+	}
+	if err := kubeutils.CreateOrUpdateResource(ctx, r.Client, desired, mutateStorageCluster); err != nil {
+		return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionStorageCluster, err)
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(utils.IBMClusterGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: StorageClusterName}, live); err != nil {
+		return ctrl.Result{}, r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionStorageCluster, err)
+	}
+	status, reason, message := storageClusterHealth(live)
+	if status == v1.ConditionTrue && !meta.IsStatusConditionTrue(fusionaccess.Status.Conditions, fusionv1alpha1.ConditionStorageCluster) {
+		r.recorder.Event(fusionaccess, corev1.EventTypeNormal, EventStorageClusterHealthy, message)
+	}
+	setCondition(fusionaccess, fusionv1alpha1.ConditionStorageCluster, status, reason, message)
+	// Changes to the IBM Cluster are not watched, as its CRD may not exist when the operator starts
+	if status != v1.ConditionTrue {
+		return waitForComponents, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// newStorageCluster returns the IBM Storage Scale Cluster for the spec. The node selectors default to the
+// storage nodes
+func newStorageCluster(spec *fusionv1alpha1.StorageClusterSpec) (*unstructured.Unstructured, error) {
+	edition := spec.License.Edition
+	if edition == "" {
+		edition = defaultStorageClusterEdition
+	}
+	storageNodes := map[string]any{common.StorageRoleLabel: common.StorageRoleValue}
+
+	cluster := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"license": map[string]any{
+				"accept":  spec.License.Accept,
+				"license": edition,
+			},
+			"daemon": map[string]any{
+				"nodeSelector": storageNodes,
+			},
+			"pmcollector": map[string]any{
+				"nodeSelector": storageNodes,
+			},
+		},
+	}}
+	cluster.SetGroupVersionKind(utils.IBMClusterGVK)
+	cluster.SetName(StorageClusterName)
+
+	if len(spec.DaemonNodeSelector) > 0 {
+		if err := unstructured.SetNestedStringMap(cluster.Object, spec.DaemonNodeSelector, "spec", "daemon", "nodeSelector"); err != nil {
+			return nil, err
+		}
+	}
+	if len(spec.PMCollectorNodeSelector) > 0 {
+		if err := unstructured.SetNestedStringMap(cluster.Object, spec.PMCollectorNodeSelector, "spec", "pmcollector", "nodeSelector"); err != nil {
+			return nil, err
+		}
+	}
+	if len(spec.ClusterProfile) > 0 {
+		if err := unstructured.SetNestedStringMap(cluster.Object, spec.ClusterProfile, "spec", "daemon", "clusterProfile"); err != nil {
+			return nil, err
+		}
+	}
+	return cluster, nil
+}
+
+// mutateStorageCluster copies the fields managed through FusionAccess to an existing IBM Cluster, e.g. one
+// created from the console, and leaves the others alone
+func mutateStorageCluster(existing, desired *unstructured.Unstructured) error {
+	kubeutils.CopyOwner(existing, desired)
+	for _, field := range storageClusterFields {
+		value, found, err := unstructured.NestedFieldCopy(desired.Object, field...)
+		if err != nil {
+			return err
+		}
+		if !found {
+			unstructured.RemoveNestedField(existing.Object, field...)
+			continue
+		}
+		if err := unstructured.SetNestedField(existing.Object, value, field...); err != nil {
+			return err
+		}
+	}
+
+	profile, _, err := unstructured.NestedStringMap(desired.Object, "spec", "daemon", "clusterProfile")
+	if err != nil {
+		return err
+	}
+	for parameter, value := range profile {
+		if err := unstructured.SetNestedField(existing.Object, value, "spec", "daemon", "clusterProfile", parameter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storageClusterHealth returns the status, reason and message of the StorageCluster condition from the
+// conditions reported by the IBM operator on the Cluster
+func storageClusterHealth(cluster *unstructured.Unstructured) (v1.ConditionStatus, string, string) {
+	pending, failed, message := ibmHealth(cluster)
+	if failed != "" {
+		return v1.ConditionFalse, fusionv1alpha1.ReasonStorageClusterUnhealthy,
+			fmt.Sprintf("The storage cluster is not %s: %s", failed, message)
+	}
+	if len(pending) > 0 {
+		return v1.ConditionFalse, fusionv1alpha1.ReasonStorageClusterProgressing,
+			fmt.Sprintf("Waiting for the storage cluster conditions: %s", strings.Join(pending, ", "))
+	}
+	return v1.ConditionTrue, fusionv1alpha1.ReasonStorageClusterHealthy, "The storage cluster is healthy"
+}
+
+// ibmHealth checks the health conditions the IBM operator reports on its resources. It returns the conditions
+// that are not reported yet, and the type and message of the first one that is False
+func ibmHealth(obj *unstructured.Unstructured) ([]string, string, string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	pending := []string{}
+	for _, condType := range ibmHealthConditions {
+		var found map[string]any
+		for _, c := range conditions {
+			if cond, ok := c.(map[string]any); ok && cond["type"] == condType {
+				found = cond
+				break
+			}
+		}
+		if found == nil || found["status"] == string(v1.ConditionUnknown) {
+			pending = append(pending, condType)
+			continue
+		}
+		if found["status"] != string(v1.ConditionTrue) {
+			return pending, condType, fmt.Sprint(found["message"])
+		}
+	}
+	return pending, "", ""
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+var _ = Describe("Storage cluster", func() {
+	var (
+		ctx          = context.Background()
+		recorder     *record.FakeRecorder
+		r            *FusionAccessReconciler
+		fusionaccess *fusionv1alpha1.FusionAccess
+	)
+
+	setup := func(objs ...client.Object) {
+		recorder = record.NewFakeRecorder(10)
+		r = newFakeReconciler(objs, withRecorder(recorder))
+	}
+
+	getCluster := func() *unstructured.Unstructured {
+		cluster := &unstructured.Unstructured{}
+		cluster.SetGroupVersionKind(utils.IBMClusterGVK)
+		Expect(r.Get(ctx, types.NamespacedName{Name: StorageClusterName}, cluster)).To(Succeed())
+		return cluster
+	}
+
+	ibmCluster := func(spec map[string]any, conditions ...any) *unstructured.Unstructured {
+		cluster := &unstructured.Unstructured{Object: map[string]any{
+			"spec":   spec,
+			"status": map[string]any{"conditions": conditions},
+		}}
+		cluster.SetGroupVersionKind(utils.IBMClusterGVK)
+		cluster.SetName(StorageClusterName)
+		return cluster
+	}
+
+	condition := func(condType, status, message string) map[string]any {
+		return map[string]any{"type": condType, "status": status, "reason": "Test", "message": message}
+	}
+
+	BeforeEach(func() {
+		fusionaccess = &fusionv1alpha1.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "ibm-fusion-access-operator"},
+			Spec: fusionv1alpha1.FusionAccessSpec{
+				StorageCluster: &fusionv1alpha1.StorageClusterSpec{
+					License: fusionv1alpha1.StorageClusterLicense{Accept: true},
+				},
+			},
+		}
+	})
+
+	It("does nothing without a storage cluster in the spec", func() {
+		setup()
+		fusionaccess.Spec.StorageCluster = nil
+		_, err := r.reconcileStorageCluster(ctx, fusionaccess, true)
+		Expect(err).ToNot(HaveOccurred())
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha1.ConditionStorageCluster)
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(fusionv1alpha1.ReasonNotApplicable))
+	})
+
+	It("waits for the IBM operator before creating the cluster", func() {
+		setup()
+		result, err := r.reconcileStorageCluster(ctx, fusionaccess, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(waitForComponents))
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha1.ConditionStorageCluster)
+		Expect(cond.Reason).To(Equal(fusionv1alpha1.ReasonStorageScaleOperatorUnavailable))
+		cluster := &unstructured.Unstructured{}
+		cluster.SetGroupVersionKind(utils.IBMClusterGVK)
+		Expect(r.Get(ctx, types.NamespacedName{Name: StorageClusterName}, cluster)).ToNot(Succeed())
+	})
+
+	It("creates the cluster on the storage nodes by default", func() {
+		setup()
+		result, err := r.reconcileStorageCluster(ctx, fusionaccess, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(waitForComponents))
+
+		cluster := getCluster()
+		Expect(cluster.GetLabels()).To(HaveKeyWithValue(common.OwnerNameLabel, resourceName))
+		Expect(cluster.GetOwnerReferences()).To(BeEmpty())
+		license, _, _ := unstructured.NestedMap(cluster.Object, "spec", "license")
+		Expect(license).To(Equal(map[string]any{"accept": true, "license": "data-management"}))
+		for _, component := range []string{"daemon", "pmcollector"} {
+			selector, _, _ := unstructured.NestedStringMap(cluster.Object, "spec", component, "nodeSelector")
+			Expect(selector).To(Equal(map[string]string{common.StorageRoleLabel: common.StorageRoleValue}))
+		}
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha1.ConditionStorageCluster)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha1.ReasonStorageClusterProgressing))
+	})
+
+	It("takes over an existing cluster and keeps the fields it does not manage", func() {
+		setup(ibmCluster(map[string]any{
+			"license": map[string]any{"accept": true, "license": "data-management"},
+			"daemon": map[string]any{
+				"nodeSelector":   map[string]any{common.StorageRoleLabel: common.StorageRoleValue},
+				"clusterProfile": map[string]any{"cloudEnv": "general", "panicOnIOHang": "no"},
+			},
+			"gui": map[string]any{"enableSessionIPCheck": true},
+		}))
+		fusionaccess.Spec.StorageCluster = &fusionv1alpha1.StorageClusterSpec{
+			License:                 fusionv1alpha1.StorageClusterLicense{Accept: true, Edition: "erasure-code"},
+			DaemonNodeSelector:      map[string]string{"node-role.kubernetes.io/worker": ""},
+			PMCollectorNodeSelector: map[string]string{"example.com/monitoring": "true"},
+			ClusterProfile:          map[string]string{"panicOnIOHang": "yes"},
+		}
+		_, err := r.reconcileStorageCluster(ctx, fusionaccess, true)
+		Expect(err).ToNot(HaveOccurred())
+
+		cluster := getCluster()
+		Expect(cluster.GetLabels()).To(HaveKeyWithValue(common.OwnerNameLabel, resourceName))
+		edition, _, _ := unstructured.NestedString(cluster.Object, "spec", "license", "license")
+		Expect(edition).To(Equal("erasure-code"))
+		daemonSelector, _, _ := unstructured.NestedStringMap(cluster.Object, "spec", "daemon", "nodeSelector")
+		Expect(daemonSelector).To(Equal(map[string]string{"node-role.kubernetes.io/worker": ""}))
+		pmcollectorSelector, _, _ := unstructured.NestedStringMap(cluster.Object, "spec", "pmcollector", "nodeSelector")
+		Expect(pmcollectorSelector).To(Equal(map[string]string{"example.com/monitoring": "true"}))
+		profile, _, _ := unstructured.NestedStringMap(cluster.Object, "spec", "daemon", "clusterProfile")
+		Expect(profile).To(Equal(map[string]string{"cloudEnv": "general", "panicOnIOHang": "yes"}))
+		gui, _, _ := unstructured.NestedBool(cluster.Object, "spec", "gui", "enableSessionIPCheck")
+		Expect(gui).To(BeTrue())
+	})
+
+	It("reports the health of the cluster", func() {
+		setup(ibmCluster(map[string]any{},
+			condition("Success", "True", "Configured"),
+			condition("Healthy", "True", "All daemons are running")))
+		result, err := r.reconcileStorageCluster(ctx, fusionaccess, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha1.ConditionStorageCluster)
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(fusionv1alpha1.ReasonStorageClusterHealthy))
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(Equal("Normal StorageClusterHealthy The storage cluster is healthy"))
+
+		// The event is not recorded again while the cluster stays healthy
+		_, err = r.reconcileStorageCluster(ctx, fusionaccess, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("reports the failures of the cluster", func() {
+		setup(ibmCluster(map[string]any{},
+			condition("Success", "True", "Configured"),
+			condition("Healthy", "False", "Quorum is lost")))
+		result, err := r.reconcileStorageCluster(ctx, fusionaccess, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(waitForComponents))
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha1.ConditionStorageCluster)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha1.ReasonStorageClusterUnhealthy))
+		Expect(cond.Message).To(ContainSubstring("Quorum is lost"))
+	})
+})
@@ -0,0 +1,199 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	v1helper "k8s.io/component-helpers/scheduling/corev1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+// reconcileStorageNodes labels the nodes selected by spec.storageNodes with the storage role and removes the
+// label from the nodes it labelled before that are not selected anymore. A node that still hosts LocalDisks
+// keeps the label, as the Storage Scale daemon holding the disks would be evicted from it. The nodes that were
+// labelled by hand and are not selected are left alone
+func (r *FusionAccessReconciler) reconcileStorageNodes(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) error {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionStorageNodes,
+			fmt.Errorf("failed to list nodes: %w", err))
+	}
+	selected, missing, err := selectStorageNodes(fusionaccess.Spec.StorageNodes, nodes.Items)
+	if err != nil {
+		return r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionStorageNodes, err)
+	}
+	localDisks, err := utils.ListIBMLocalDisks(ctx, r.Client)
+	if err != nil {
+		return r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionStorageNodes, err)
+	}
+
+	labelled, unlabelled, retained := []string{}, []string{}, []string{}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		switch {
+		case slices.Contains(selected, node.Name):
+			if isManagedStorageNode(node, fusionaccess) {
+				continue
+			}
+			patch := client.MergeFrom(node.DeepCopy())
+			if err := kubeutils.SetOwner(fusionaccess, node, r.Scheme); err != nil {
+				return r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionStorageNodes, err)
+			}
+			node.Labels[common.StorageRoleLabel] = common.StorageRoleValue
+			if err := r.Patch(ctx, node, patch); err != nil {
+				return r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionStorageNodes,
+					fmt.Errorf("failed to label node %s: %w", node.Name, err))
+			}
+			labelled = append(labelled, node.Name)
+		case isOwnedNode(node, fusionaccess):
+			if hostsLocalDisks(node.Name, localDisks) {
+				retained = append(retained, node.Name)
+				continue
+			}
+			patch := client.MergeFrom(node.DeepCopy())
+			delete(node.Labels, common.StorageRoleLabel)
+			delete(node.Labels, common.OwnerNameLabel)
+			delete(node.Labels, common.OwnerNamespaceLabel)
+			if err := r.Patch(ctx, node, patch); err != nil {
+				return r.componentFailed(ctx, fusionaccess, fusionv1alpha1.ConditionStorageNodes,
+					fmt.Errorf("failed to remove the storage role from node %s: %w", node.Name, err))
+			}
+			unlabelled = append(unlabelled, node.Name)
+		}
+	}
+
+	if len(labelled) > 0 {
+		log.Log.Info("Added the storage role to nodes", "nodes", labelled)
+		r.recorder.Eventf(fusionaccess, corev1.EventTypeNormal, EventStorageNodesUpdated,
+			"Added the storage role to nodes %s", strings.Join(labelled, ", "))
+	}
+	if len(unlabelled) > 0 {
+		log.Log.Info("Removed the storage role from nodes", "nodes", unlabelled)
+		r.recorder.Eventf(fusionaccess, corev1.EventTypeNormal, EventStorageNodesUpdated,
+			"Removed the storage role from nodes %s", strings.Join(unlabelled, ", "))
+	}
+	setStorageNodesStatus(fusionaccess, selected, missing, retained)
+	if len(retained) > 0 {
+		r.recorder.Eventf(fusionaccess, corev1.EventTypeWarning, EventStorageNodesInUse,
+			"Nodes %s are not selected anymore but still host LocalDisks", strings.Join(retained, ", "))
+	}
+	return nil
+}
+
+// selectStorageNodes returns the sorted names of the nodes selected by the spec, and the names listed in the
+// spec that do not match any node
+func selectStorageNodes(spec *fusionv1alpha1.StorageNodesSpec, nodes []corev1.Node) ([]string, []string, error) {
+	selected, missing := []string{}, []string{}
+	if spec == nil {
+		return selected, missing, nil
+	}
+	if spec.NodeSelector != nil {
+		for i := range nodes {
+			matches, err := v1helper.MatchNodeSelectorTerms(&nodes[i], spec.NodeSelector)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid storage node selector: %w", err)
+			}
+			if matches {
+				selected = append(selected, nodes[i].Name)
+			}
+		}
+		slices.Sort(selected)
+		return selected, missing, nil
+	}
+	for _, name := range spec.Nodes {
+		if slices.ContainsFunc(nodes, func(node corev1.Node) bool { return node.Name == name }) {
+			selected = append(selected, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	slices.Sort(selected)
+	selected = slices.Compact(selected)
+	return selected, missing, nil
+}
+
+// setStorageNodesStatus reports the storage nodes and sets the StorageNodes condition
+func setStorageNodesStatus(fusionaccess *fusionv1alpha1.FusionAccess, selected, missing, retained []string) {
+	if fusionaccess.Spec.StorageNodes == nil && len(retained) == 0 {
+		fusionaccess.Status.StorageNodes = nil
+		setCondition(fusionaccess, fusionv1alpha1.ConditionStorageNodes, v1.ConditionTrue,
+			fusionv1alpha1.ReasonNotApplicable, "No storage nodes are set in the spec")
+		return
+	}
+	fusionaccess.Status.StorageNodes = &fusionv1alpha1.StorageNodesStatus{Selected: selected, Retained: retained}
+
+	switch {
+	case len(missing) > 0:
+		setCondition(fusionaccess, fusionv1alpha1.ConditionStorageNodes, v1.ConditionFalse,
+			fusionv1alpha1.ReasonNodesNotFound, fmt.Sprintf("Nodes not found: %s", strings.Join(missing, ", ")))
+	case len(retained) > 0:
+		setCondition(fusionaccess, fusionv1alpha1.ConditionStorageNodes, v1.ConditionFalse,
+			fusionv1alpha1.ReasonNodesInUse, fmt.Sprintf(
+				"Nodes %s keep the storage role as they host LocalDisks, remove the disks from Storage Scale first",
+				strings.Join(retained, ", ")))
+	default:
+		setCondition(fusionaccess, fusionv1alpha1.ConditionStorageNodes, v1.ConditionTrue,
+			fusionv1alpha1.ReasonNodesLabelled, fmt.Sprintf("%d storage nodes are labelled", len(selected)))
+	}
+}
+
+// isOwnedNode returns true if the node was labelled as storage node for this FusionAccess
+func isOwnedNode(node *corev1.Node, fusionaccess *fusionv1alpha1.FusionAccess) bool {
+	return node.Labels[common.OwnerNameLabel] == fusionaccess.Name &&
+		node.Labels[common.OwnerNamespaceLabel] == fusionaccess.Namespace
+}
+
+// isManagedStorageNode returns true if the node carries the storage role and is owned by this FusionAccess
+func isManagedStorageNode(node *corev1.Node, fusionaccess *fusionv1alpha1.FusionAccess) bool {
+	return isOwnedNode(node, fusionaccess) && node.Labels[common.StorageRoleLabel] == common.StorageRoleValue
+}
+
+// hostsLocalDisks returns true if one of the LocalDisks is on the node
+func hostsLocalDisks(nodeName string, localDisks []unstructured.Unstructured) bool {
+	for _, localDisk := range localDisks {
+		if node, _, _ := unstructured.NestedString(localDisk.Object, "spec", "node"); node == nodeName {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeLabelsChanged ignores the node updates that do not change its labels, like the frequent status updates
+func nodeLabelsChanged() builder.WatchesOption {
+	return builder.WithPredicates(predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+	})
+}
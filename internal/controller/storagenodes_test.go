@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+var _ = Describe("Storage nodes", func() {
+	var (
+		ctx          = context.Background()
+		recorder     *record.FakeRecorder
+		r            *FusionAccessReconciler
+		fusionaccess *fusionv1alpha1.FusionAccess
+	)
+
+	const namespace = "ibm-fusion-access-operator"
+
+	ownerLabels := map[string]string{
+		common.OwnerNameLabel:      resourceName,
+		common.OwnerNamespaceLabel: namespace,
+		common.StorageRoleLabel:    common.StorageRoleValue,
+	}
+
+	newNode := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	localDisk := func(name, node string) *unstructured.Unstructured {
+		disk := &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{"node": node, "device": "/dev/sdb"},
+		}}
+		disk.SetGroupVersionKind(utils.IBMLocalDiskGVK)
+		disk.SetName(name)
+		disk.SetNamespace("ibm-spectrum-scale")
+		return disk
+	}
+
+	setup := func(objs ...client.Object) {
+		recorder = record.NewFakeRecorder(10)
+		r = newFakeReconciler(objs, withStatusSubresource(&fusionv1alpha1.FusionAccess{}), withRecorder(recorder))
+	}
+
+	nodeLabels := func(name string) map[string]string {
+		node := &corev1.Node{}
+		Expect(r.Get(ctx, types.NamespacedName{Name: name}, node)).To(Succeed())
+		return node.Labels
+	}
+
+	condition := func() *metav1.Condition {
+		return meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha1.ConditionStorageNodes)
+	}
+
+	BeforeEach(func() {
+		fusionaccess = &fusionv1alpha1.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace},
+		}
+	})
+
+	It("labels the nodes matching the selector", func() {
+		setup(fusionaccess,
+			newNode("worker-0", map[string]string{"node-role.kubernetes.io/worker": ""}),
+			newNode("worker-1", map[string]string{"node-role.kubernetes.io/worker": ""}),
+			newNode("master-0", map[string]string{"node-role.kubernetes.io/master": ""}))
+		fusionaccess.Spec.StorageNodes = &fusionv1alpha1.StorageNodesSpec{
+			NodeSelector: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      "node-role.kubernetes.io/worker",
+					Operator: corev1.NodeSelectorOpExists,
+				}},
+			}}},
+		}
+		Expect(r.reconcileStorageNodes(ctx, fusionaccess)).To(Succeed())
+
+		Expect(nodeLabels("worker-0")).To(HaveKeyWithValue(common.StorageRoleLabel, common.StorageRoleValue))
+		Expect(nodeLabels("worker-1")).To(HaveKeyWithValue(common.OwnerNameLabel, resourceName))
+		Expect(nodeLabels("master-0")).ToNot(HaveKey(common.StorageRoleLabel))
+		Expect(fusionaccess.Status.StorageNodes.Selected).To(Equal([]string{"worker-0", "worker-1"}))
+		Expect(condition().Reason).To(Equal(fusionv1alpha1.ReasonNodesLabelled))
+		Expect(<-recorder.Events).To(Equal("Normal StorageNodesUpdated Added the storage role to nodes worker-0, worker-1"))
+
+		// Nothing is changed once the nodes are labelled
+		Expect(r.reconcileStorageNodes(ctx, fusionaccess)).To(Succeed())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("reports the listed nodes that do not exist", func() {
+		setup(fusionaccess, newNode("worker-0", nil))
+		fusionaccess.Spec.StorageNodes = &fusionv1alpha1.StorageNodesSpec{Nodes: []string{"worker-0", "worker-9"}}
+		Expect(r.reconcileStorageNodes(ctx, fusionaccess)).To(Succeed())
+
+		Expect(nodeLabels("worker-0")).To(HaveKeyWithValue(common.StorageRoleLabel, common.StorageRoleValue))
+		Expect(fusionaccess.Status.StorageNodes.Selected).To(Equal([]string{"worker-0"}))
+		Expect(condition().Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition().Reason).To(Equal(fusionv1alpha1.ReasonNodesNotFound))
+		Expect(condition().Message).To(ContainSubstring("worker-9"))
+	})
+
+	It("removes the storage role from the nodes it labelled only", func() {
+		setup(fusionaccess,
+			newNode("worker-0", ownerLabels),
+			newNode("worker-1", ownerLabels),
+			newNode("worker-2", map[string]string{common.StorageRoleLabel: common.StorageRoleValue}))
+		fusionaccess.Spec.StorageNodes = &fusionv1alpha1.StorageNodesSpec{Nodes: []string{"worker-0"}}
+		Expect(r.reconcileStorageNodes(ctx, fusionaccess)).To(Succeed())
+
+		Expect(nodeLabels("worker-0")).To(HaveKeyWithValue(common.StorageRoleLabel, common.StorageRoleValue))
+		Expect(nodeLabels("worker-1")).To(BeEmpty())
+		Expect(nodeLabels("worker-2")).To(HaveKeyWithValue(common.StorageRoleLabel, common.StorageRoleValue))
+		Expect(<-recorder.Events).To(Equal("Normal StorageNodesUpdated Removed the storage role from nodes worker-1"))
+	})
+
+	It("refuses to remove the storage role from the nodes with LocalDisks", func() {
+		setup(fusionaccess,
+			newNode("worker-0", ownerLabels),
+			newNode("worker-1", ownerLabels),
+			localDisk("worker-1-sdb", "worker-1"))
+		fusionaccess.Spec.StorageNodes = &fusionv1alpha1.StorageNodesSpec{Nodes: []string{"worker-0"}}
+		Expect(r.reconcileStorageNodes(ctx, fusionaccess)).To(Succeed())
+
+		Expect(nodeLabels("worker-1")).To(Equal(ownerLabels))
+		Expect(fusionaccess.Status.StorageNodes.Retained).To(Equal([]string{"worker-1"}))
+		Expect(condition().Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition().Reason).To(Equal(fusionv1alpha1.ReasonNodesInUse))
+		Expect(<-recorder.Events).To(Equal("Warning StorageNodesInUse Nodes worker-1 are not selected anymore but still host LocalDisks"))
+	})
+
+	It("removes the storage role when the spec does not select nodes anymore", func() {
+		setup(fusionaccess, newNode("worker-0", ownerLabels))
+		Expect(r.reconcileStorageNodes(ctx, fusionaccess)).To(Succeed())
+
+		Expect(nodeLabels("worker-0")).To(BeEmpty())
+		Expect(fusionaccess.Status.StorageNodes).To(BeNil())
+		Expect(condition().Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition().Reason).To(Equal(fusionv1alpha1.ReasonNotApplicable))
+	})
+})
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+// StorageScaleReleaseReconciler keeps the StorageScaleRelease objects in sync with the release metadata
+// shipped in files/, so that changing or deleting one of them is reverted
+type StorageScaleReleaseReconciler struct {
+	client.Client
+}
+
+//+kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=storagescalereleases,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile syncs the whole catalog, whichever release triggered it
+func (r *StorageScaleReleaseReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	return ctrl.Result{}, SyncStorageScaleReleases(ctx, r.Client)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *StorageScaleReleaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&fusionv1alpha1.StorageScaleRelease{}).
+		// The catalog is published on start, even when no StorageScaleRelease exists yet
+		WatchesRawSource(source.Func(func(_ context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+			queue.Add(reconcile.Request{})
+			return nil
+		})).
+		Complete(r)
+}
+
+// SyncStorageScaleReleases makes the StorageScaleRelease objects match the release metadata shipped in files/.
+// Releases that are not shipped anymore are removed and any manual change to the objects is overwritten
+func SyncStorageScaleReleases(ctx context.Context, cl client.Client) error {
+	releases, err := utils.GetStorageScaleReleases()
+	if err != nil {
+		return fmt.Errorf("failed to load the Storage Scale releases: %w", err)
+	}
+
+	shipped := map[string]bool{}
+	for _, data := range releases {
+		release := newStorageScaleRelease(data)
+		shipped[release.Name] = true
+		if err := kubeutils.CreateOrUpdateResource(ctx, cl, release,
+			func(existing, desired *fusionv1alpha1.StorageScaleRelease) error {
+				existing.Spec = desired.Spec
+				return nil
+			}); err != nil {
+			return err
+		}
+	}
+
+	existing := &fusionv1alpha1.StorageScaleReleaseList{}
+	if err := cl.List(ctx, existing); err != nil {
+		return err
+	}
+	for i := range existing.Items {
+		release := &existing.Items[i]
+		if shipped[release.Name] {
+			continue
+		}
+		log.Log.Info(fmt.Sprintf("Removing StorageScaleRelease %s as it is not shipped anymore", release.Name))
+		if err := cl.Delete(ctx, release); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newStorageScaleRelease(data utils.FusionAccessData) *fusionv1alpha1.StorageScaleRelease {
+	return &fusionv1alpha1.StorageScaleRelease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: data.Version,
+		},
+		Spec: fusionv1alpha1.StorageScaleReleaseSpec{
+			Version:                   data.Version,
+			CSIVersion:                data.CSIVersion,
+			Architectures:             data.Architecture,
+			OpenShiftLevels:           data.OpenShiftLevels,
+			RemoteStorageClusterLevel: data.RemoteStorageClusterLevel,
+			FileSystemVersion:         data.FileSystemVersion,
+			UpgradeFrom:               data.UpgradeFrom,
+		},
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("SyncStorageScaleReleases", func() {
+	ctx := context.Background()
+
+	It("publishes the shipped releases and removes stale ones", func() {
+		stale := &fusionv1alpha.StorageScaleRelease{
+			ObjectMeta: metav1.ObjectMeta{Name: "v5.2.1.1"},
+			Spec:       fusionv1alpha.StorageScaleReleaseSpec{Version: "v5.2.1.1"},
+		}
+		tampered := &fusionv1alpha.StorageScaleRelease{
+			ObjectMeta: metav1.ObjectMeta{Name: "v5.2.3.1.dev3"},
+			Spec:       fusionv1alpha.StorageScaleReleaseSpec{Version: "v5.2.3.1.dev3", CSIVersion: "0.0.1"},
+		}
+		cl := fake.NewClientBuilder().WithScheme(createFakeScheme()).WithObjects(stale, tampered).Build()
+
+		Expect(SyncStorageScaleReleases(ctx, cl)).To(Succeed())
+
+		releases := &fusionv1alpha.StorageScaleReleaseList{}
+		Expect(cl.List(ctx, releases)).To(Succeed())
+		Expect(releases.Items).To(HaveLen(1))
+
+		release := &fusionv1alpha.StorageScaleRelease{}
+		Expect(cl.Get(ctx, types.NamespacedName{Name: "v5.2.3.1.dev3"}, release)).To(Succeed())
+		Expect(release.Spec.Version).To(Equal("v5.2.3.1.dev3"))
+		Expect(release.Spec.CSIVersion).To(Equal("2.13.1"))
+		Expect(release.Spec.OpenShiftLevels).To(ContainElement("4.18"))
+		Expect(release.Spec.UpgradeFrom).To(ContainElement("v5.2.3.0"))
+	})
+
+	It("puts back a release that was deleted", func() {
+		cl := fake.NewClientBuilder().WithScheme(createFakeScheme()).Build()
+		r := &StorageScaleReleaseReconciler{Client: cl}
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ToNot(HaveOccurred())
+
+		key := types.NamespacedName{Name: "v5.2.3.1.dev3"}
+		release := &fusionv1alpha.StorageScaleRelease{}
+		Expect(cl.Get(ctx, key, release)).To(Succeed())
+		Expect(cl.Delete(ctx, release)).To(Succeed())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cl.Get(ctx, key, release)).To(Succeed())
+		Expect(release.Spec.CSIVersion).To(Equal("2.13.1"))
+	})
+})
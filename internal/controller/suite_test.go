@@ -27,17 +27,21 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	consolev1 "github.com/openshift/api/console/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
 	kmmv1beta1 "github.com/rh-ecosystem-edge/kernel-module-management/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	kruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/kubeutils"
 )
 
 // These tests use Ginkgo (BDD-style Go testing framework). Refer to
@@ -101,8 +105,39 @@ func createFakeScheme() *kruntime.Scheme {
 		configv1.AddToScheme,
 		consolev1.AddToScheme,
 		operatorv1.AddToScheme,
+		operatorv1alpha1.AddToScheme,
 		kmmv1beta1.AddToScheme,
 	)
 	Expect(builder.AddToScheme(s)).To(Succeed())
 	return s
 }
+
+// fakeReconcilerOption configures the FusionAccessReconciler that newFakeReconciler builds
+type fakeReconcilerOption func(*fake.ClientBuilder, *FusionAccessReconciler)
+
+// withStatusSubresource lets the fake client handle the status of the objects as a subresource
+func withStatusSubresource(objs ...client.Object) fakeReconcilerOption {
+	return func(builder *fake.ClientBuilder, _ *FusionAccessReconciler) {
+		builder.WithStatusSubresource(objs...)
+	}
+}
+
+// withRecorder records the events of the reconciler with recorder
+func withRecorder(recorder record.EventRecorder) fakeReconcilerOption {
+	return func(_ *fake.ClientBuilder, r *FusionAccessReconciler) {
+		r.recorder = kubeutils.NewEventRecorder(recorder, kubeutils.DefaultEventInterval)
+	}
+}
+
+// newFakeReconciler returns a FusionAccessReconciler on a fake client that holds the objects. The other
+// reconcilers are built from its client, scheme and recorder
+func newFakeReconciler(objs []client.Object, opts ...fakeReconcilerOption) *FusionAccessReconciler {
+	s := createFakeScheme()
+	builder := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...)
+	r := &FusionAccessReconciler{Scheme: s}
+	for _, opt := range opts {
+		opt(builder, r)
+	}
+	r.Client = builder.Build()
+	return r
+}
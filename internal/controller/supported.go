@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+// reconcileSupported checks the Storage Scale version against the current OpenShift version and the node
+// architectures. It runs on every reconcile, so it is recomputed when the ClusterVersion or the release
+// catalog changes
+func (r *FusionAccessReconciler) reconcileSupported(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) error {
+	version := fusionaccess.Status.InstalledStorageScaleVersion
+	if version == "" {
+		version = string(fusionaccess.Spec.StorageScaleVersion)
+	}
+	if version == "" {
+		setCondition(fusionaccess, fusionv1alpha1.ConditionSupported, v1.ConditionUnknown,
+			fusionv1alpha1.ReasonNotApplicable, "No Storage Scale version is set, the support matrix of an external manifest is not known")
+		return nil
+	}
+
+	release, err := utils.GetStorageScaleRelease(version)
+	if err != nil {
+		return err
+	}
+	clusterVersion := &configv1.ClusterVersion{}
+	if err := r.Get(ctx, types.NamespacedName{Name: "version"}, clusterVersion); err != nil {
+		return fmt.Errorf("failed to get the ClusterVersion: %w", err)
+	}
+	current, err := utils.GetCurrentClusterVersion(clusterVersion)
+	if err != nil {
+		return err
+	}
+	architectures, err := utils.GetNodeArchitectures(ctx, r.Client)
+	if err != nil {
+		return err
+	}
+	unsupported, err := utils.UnsupportedArchitectures(version, architectures)
+	if err != nil {
+		return err
+	}
+
+	matrix := fmt.Sprintf("IBM Storage Scale %s supports OpenShift %s on %s with CSI %s", version,
+		strings.Join(release.OpenShiftLevels, ", "), strings.Join(release.Architecture, ", "), release.CSIVersion)
+	switch {
+	case !utils.IsOpenShiftSupported(version, *current):
+		setCondition(fusionaccess, fusionv1alpha1.ConditionSupported, v1.ConditionFalse,
+			fusionv1alpha1.ReasonOpenShiftVersionUnsupported, fmt.Sprintf("OpenShift %s is not supported. %s", current, matrix))
+	case len(unsupported) > 0:
+		setCondition(fusionaccess, fusionv1alpha1.ConditionSupported, v1.ConditionFalse,
+			fusionv1alpha1.ReasonArchitectureUnsupported,
+			fmt.Sprintf("Nodes with the %s architectures are not supported. %s", strings.Join(unsupported, ", "), matrix))
+	default:
+		setCondition(fusionaccess, fusionv1alpha1.ConditionSupported, v1.ConditionTrue,
+			fusionv1alpha1.ReasonSupportedConfiguration, fmt.Sprintf("OpenShift %s is supported. %s", current, matrix))
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("Supported", func() {
+	var (
+		ctx          = context.Background()
+		r            *FusionAccessReconciler
+		fusionaccess *fusionv1alpha.FusionAccess
+	)
+
+	newNode := func(name, arch string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{Architecture: arch}},
+		}
+	}
+
+	setup := func(objs ...client.Object) {
+		r = newFakeReconciler(objs)
+	}
+
+	BeforeEach(func() {
+		fusionaccess = &fusionv1alpha.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "ibm-fusion-access-operator"},
+			Spec:       fusionv1alpha.FusionAccessSpec{StorageScaleVersion: "v5.2.3.1.dev3"},
+		}
+	})
+
+	It("lists the support matrix of a supported cluster", func() {
+		setup(newOCPVersion("4.18.4"), newNode("worker-0", "amd64"), newNode("worker-1", "s390x"))
+		Expect(r.reconcileSupported(ctx, fusionaccess)).To(Succeed())
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionSupported)
+		Expect(cond).ToNot(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonSupportedConfiguration))
+		Expect(cond.Message).To(Equal("OpenShift 4.18.4 is supported. IBM Storage Scale v5.2.3.1.dev3 supports " +
+			"OpenShift 4.16, 4.17, 4.18 on x86_64, ppc64le, s390x with CSI 2.13.1"))
+	})
+
+	It("reports an unsupported OpenShift version", func() {
+		setup(newOCPVersion("4.19.0"), newNode("worker-0", "amd64"))
+		Expect(r.reconcileSupported(ctx, fusionaccess)).To(Succeed())
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionSupported)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonOpenShiftVersionUnsupported))
+		Expect(cond.Message).To(HavePrefix("OpenShift 4.19.0 is not supported."))
+	})
+
+	It("reports unsupported node architectures", func() {
+		setup(newOCPVersion("4.18.4"), newNode("worker-0", "amd64"), newNode("worker-1", "arm64"))
+		Expect(r.reconcileSupported(ctx, fusionaccess)).To(Succeed())
+
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionSupported)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonArchitectureUnsupported))
+		Expect(cond.Message).To(HavePrefix("Nodes with the aarch64 architectures are not supported."))
+	})
+
+	It("is unknown for an external manifest", func() {
+		fusionaccess.Spec.StorageScaleVersion = ""
+		setup()
+		Expect(r.reconcileSupported(ctx, fusionaccess)).To(Succeed())
+		cond := meta.FindStatusCondition(fusionaccess.Status.Conditions, fusionv1alpha.ConditionSupported)
+		Expect(cond.Status).To(Equal(metav1.ConditionUnknown))
+	})
+})
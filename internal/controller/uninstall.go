@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	mfc "github.com/manifestival/controller-runtime-client"
+	"github.com/manifestival/manifestival"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/console"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/kernelmodule"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/localvolumediscovery"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+const storageScaleFinalizer = "fusion.storage.openshift.io/finalizer"
+
+// waitForFilesystemsRemoval is how often we check again if the IBM filesystems are gone during an uninstall
+var waitForFilesystemsRemoval = ctrl.Result{RequeueAfter: 30 * time.Second}
+
+// teardownStep is a single step of the uninstall of a FusionAccess object. Steps are run in order and
+// each of them must be idempotent as a failing step restarts the whole teardown on the next reconcile
+type teardownStep struct {
+	reason  string
+	message string
+	run     func(ctx context.Context, ns string, fusionaccess *fusionv1alpha1.FusionAccess) error
+}
+
+func (r *FusionAccessReconciler) teardownSteps() []teardownStep {
+	return []teardownStep{
+		{"RemovingDeviceDiscovery", "Removing the device discovery", r.removeDeviceDiscovery},
+		{"RemovingConsolePlugin", "Removing the console plugin", r.removeConsolePlugin},
+		{"RemovingKernelModule", "Removing the kernel module and restoring the global pull secret", r.removeKernelModule},
+		{"RemovingEntitlementSecrets", "Removing the IBM entitlement secrets", r.removeEntitlementSecrets},
+		{"RemovingStorageScaleManifest", "Removing the IBM Storage Scale manifest", r.removeStorageScaleManifest},
+	}
+}
+
+// finalizeFusionAccess tears down everything the operator created for a FusionAccess object. It refuses
+// to do anything as long as IBM filesystems exist, as removing the IBM operator underneath them would
+// leave the data in an unmanageable state
+func (r *FusionAccessReconciler) finalizeFusionAccess(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) (ctrl.Result, error) {
+	filesystems, err := utils.ListIBMFilesystems(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(filesystems) > 0 {
+		log.Log.Info("Waiting for IBM filesystems to be removed before uninstalling", "filesystems", filesystems)
+		if err := r.setUninstallCondition(ctx, fusionaccess, v1.ConditionFalse, "FilesystemsPresent",
+			fmt.Sprintf("Uninstall is blocked until the following filesystems are deleted: %s", strings.Join(filesystems, ", "))); err != nil {
+			return ctrl.Result{}, err
+		}
+		return waitForFilesystemsRemoval, nil
+	}
+
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for _, step := range r.teardownSteps() {
+		if err := r.setUninstallCondition(ctx, fusionaccess, v1.ConditionTrue, step.reason, step.message); err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Log.Info(step.message)
+		if err := step.run(ctx, ns, fusionaccess); err != nil {
+			log.Log.Error(err, "Uninstall step failed", "step", step.reason)
+			serr := r.setUninstallCondition(ctx, fusionaccess, v1.ConditionFalse, step.reason+"Failed", err.Error())
+			if serr != nil {
+				log.Log.Error(serr, "Failed to update uninstall condition")
+			}
+			return ctrl.Result{}, err
+		}
+	}
+	log.Log.Info("Successfully finalized FusionAccess")
+
+	controllerutil.RemoveFinalizer(fusionaccess, storageScaleFinalizer)
+	if err := r.Update(ctx, fusionaccess); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *FusionAccessReconciler) setUninstallCondition(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess,
+	status v1.ConditionStatus, reason, message string) error {
+	fusionaccess.Status.Status = fusionv1alpha1.ConditionUninstalling
+	setCondition(fusionaccess, fusionv1alpha1.ConditionUninstalling, status, reason, message)
+	setCondition(fusionaccess, fusionv1alpha1.ConditionReady, v1.ConditionFalse, fusionv1alpha1.ReasonUninstalling,
+		"FusionAccess is being deleted")
+	return r.Status().Update(ctx, fusionaccess)
+}
+
+func (r *FusionAccessReconciler) removeDeviceDiscovery(ctx context.Context, ns string, _ *fusionv1alpha1.FusionAccess) error {
+	return localvolumediscovery.DeleteLocalVolumeDiscovery(ctx, localvolumediscovery.NewLocalVolumeDiscovery(ns), r.Client)
+}
+
+func (r *FusionAccessReconciler) removeConsolePlugin(ctx context.Context, _ string, _ *fusionv1alpha1.FusionAccess) error {
+	if err := console.DisablePlugin(ctx, r.Client); err != nil {
+		return err
+	}
+	return console.DeletePlugin(ctx, r.Client)
+}
+
+func (r *FusionAccessReconciler) removeKernelModule(ctx context.Context, _ string, _ *fusionv1alpha1.FusionAccess) error {
+	return kernelmodule.DeleteKMMResources(ctx, r.Client)
+}
+
+func (r *FusionAccessReconciler) removeEntitlementSecrets(ctx context.Context, ns string, _ *fusionv1alpha1.FusionAccess) error {
+	if err := r.fullClient.CoreV1().Pods(ns).Delete(ctx, utils.CheckPodName, v1.DeleteOptions{}); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	return deleteEntitlementPullSecrets(ctx, r.fullClient, ns)
+}
+
+func (r *FusionAccessReconciler) removeStorageScaleManifest(ctx context.Context, ns string, fusionaccess *fusionv1alpha1.FusionAccess) error {
+	install_path := ""
+	if fusionaccess.Spec.ManifestSource == nil {
+		var err error
+		if install_path, err = getIbmManifest(fusionaccess.Spec); err != nil {
+			// Nothing could have been applied with a spec we cannot resolve to a manifest
+			log.Log.Info("No Storage Scale manifest to remove", "reason", err.Error())
+			return nil
+		}
+	}
+	if usesExternalManifest(fusionaccess.Spec) {
+		// Falls back to the cached copy, so the removal does not depend on the source still being available
+		manifest, err := r.loadExternalManifest(ctx, fusionaccess)
+		if err != nil {
+			return err
+		}
+		install_path = manifest.Path
+	}
+	installManifest, err := manifestival.NewManifest(
+		install_path,
+		manifestival.UseClient(mfc.NewClient(r.Client)),
+	)
+	if err != nil {
+		return err
+	}
+	log.Log.Info(fmt.Sprintf("Deleting manifest from %s", install_path))
+	return installManifest.Delete(manifestival.IgnoreNotFound(true))
+}
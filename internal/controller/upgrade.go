@@ -0,0 +1,233 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/manifestival/manifestival"
+	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/kernelmodule"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+const (
+	// IBMCoreNamespace is where the IBM operator runs the Storage Scale core daemons
+	IBMCoreNamespace = "ibm-spectrum-scale"
+	// IBMCoreDaemonLabel selects the Storage Scale core daemon pods
+	IBMCoreDaemonLabel = "app.kubernetes.io/name"
+	IBMCoreDaemonValue = "core"
+)
+
+// waitForUpgradeRollout is how often we check again if the workloads rolled out during an upgrade
+var waitForUpgradeRollout = ctrl.Result{RequeueAfter: 15 * time.Second}
+
+// IBMOperatorDeployments are the IBM operator deployments reported in the StorageScaleOperator condition
+var IBMOperatorDeployments = []types.NamespacedName{
+	{Namespace: "ibm-spectrum-scale-operator", Name: "ibm-spectrum-scale-controller-manager"},
+	{Namespace: "ibm-spectrum-scale-csi", Name: "ibm-spectrum-scale-csi-operator"},
+}
+
+// isUpgrade returns true when a different Storage Scale version than the one already rolled out is requested
+func isUpgrade(fusionaccess *fusionv1alpha1.FusionAccess) bool {
+	installed := strings.TrimPrefix(fusionaccess.Status.InstalledStorageScaleVersion, "v")
+	requested := strings.TrimPrefix(string(fusionaccess.Spec.StorageScaleVersion), "v")
+	return installed != "" && requested != "" && installed != requested
+}
+
+// reconcileUpgrade moves an existing installation to the version in the spec. Every step is idempotent
+// so the whole sequence is simply re-run on each reconcile until all of the rollouts are done.
+// It returns true once the upgrade has completed
+func (r *FusionAccessReconciler) reconcileUpgrade(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	installManifest manifestival.Manifest,
+) (bool, ctrl.Result, error) {
+	from := fusionaccess.Status.InstalledStorageScaleVersion
+	to := string(fusionaccess.Spec.StorageScaleVersion)
+
+	// The webhook already enforces this, but it may be disabled. Retrying does not help,
+	// the FusionAccess is reconciled again once the version is changed
+	if err := utils.IsStorageScaleUpgradeAllowed(from, to); err != nil {
+		log.Log.Error(err, "Storage Scale upgrade not allowed", "from", from, "to", to)
+		return false, ctrl.Result{}, r.setUpgradeCondition(ctx, fusionaccess, v1.ConditionFalse, "UpgradeNotAllowed", from, to, err.Error())
+	}
+
+	// CRDs go first so that the new operator never starts against old schemas
+	if err := r.setUpgradeCondition(ctx, fusionaccess, v1.ConditionTrue, "ApplyingCRDs", from, to, "applying the new CRDs"); err != nil {
+		return false, ctrl.Result{}, err
+	}
+	if err := installManifest.Filter(manifestival.ByKind("CustomResourceDefinition")).Apply(); err != nil {
+		serr := r.setUpgradeCondition(ctx, fusionaccess, v1.ConditionFalse, "ApplyingCRDsFailed", from, to, err.Error())
+		return false, ctrl.Result{}, errors.Join(serr, err)
+	}
+
+	if err := r.setUpgradeCondition(ctx, fusionaccess, v1.ConditionTrue, "ApplyingWorkloads", from, to, "applying the new workloads"); err != nil {
+		return false, ctrl.Result{}, err
+	}
+	if err := installManifest.Filter(manifestival.Not(manifestival.ByKind("CustomResourceDefinition"))).Apply(); err != nil {
+		serr := r.setUpgradeCondition(ctx, fusionaccess, v1.ConditionFalse, "ApplyingWorkloadsFailed", from, to, err.Error())
+		return false, ctrl.Result{}, errors.Join(serr, err)
+	}
+
+	// The deployments come from the new manifest, which may have renamed them. They were just
+	// applied, so one that does not exist is an error rather than something to wait for
+	for _, deployment := range manifestDeployments(installManifest) {
+		rolledOut, err := r.isDeploymentRolledOut(ctx, deployment)
+		if err != nil {
+			return false, ctrl.Result{}, fmt.Errorf("failed to check the rollout of deployment %s: %w", deployment, err)
+		}
+		if !rolledOut {
+			log.Log.Info("Waiting for deployment rollout", "deployment", deployment)
+			err := r.setUpgradeCondition(ctx, fusionaccess, v1.ConditionTrue, "WaitingForOperatorRollout", from, to,
+				fmt.Sprintf("waiting for deployment %s to roll out", deployment))
+			return false, waitForUpgradeRollout, err
+		}
+	}
+
+	// The kernel module is built from the coreInit image, so it has to follow the new version.
+	// Like on install, it only exists once the pull secret has been provided
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return false, ctrl.Result{}, err
+	}
+	if _, err := getPullSecretContent(FUSIONPULLSECRETNAME, ns, ctx, r.fullClient); err == nil {
+		if err := r.setUpgradeCondition(ctx, fusionaccess, v1.ConditionTrue, "UpdatingKernelModule", from, to, "updating the kernel module"); err != nil {
+			return false, ctrl.Result{}, err
+		}
+		if err := kernelmodule.CreateOrUpdateKMMResources(ctx, r.Client, fusionaccess); err != nil {
+			serr := r.setUpgradeCondition(ctx, fusionaccess, v1.ConditionFalse, "UpdatingKernelModuleFailed", from, to, err.Error())
+			return false, ctrl.Result{}, errors.Join(serr, err)
+		}
+	}
+
+	coreInit, err := getManifestCoreInitImage(installManifest)
+	if err != nil {
+		return false, ctrl.Result{}, err
+	}
+	pending, err := r.pendingCoreDaemons(ctx, coreInit)
+	if err != nil {
+		return false, ctrl.Result{}, err
+	}
+	if len(pending) > 0 {
+		log.Log.Info("Waiting for core daemons rollout", "pods", pending)
+		err := r.setUpgradeCondition(ctx, fusionaccess, v1.ConditionTrue, "WaitingForCoreDaemons", from, to,
+			fmt.Sprintf("waiting for %d core daemons to run %s", len(pending), coreInit))
+		return false, waitForUpgradeRollout, err
+	}
+
+	log.Log.Info("Storage Scale upgrade completed", "from", from, "to", to)
+	fusionaccess.Status.InstalledStorageScaleVersion = to
+	if err := r.setUpgradeCondition(ctx, fusionaccess, v1.ConditionFalse, "UpgradeCompleted", from, to, "upgrade completed"); err != nil {
+		return false, ctrl.Result{}, err
+	}
+	return true, ctrl.Result{}, nil
+}
+
+func (r *FusionAccessReconciler) setUpgradeCondition(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess,
+	status v1.ConditionStatus, reason, from, to, message string) error {
+	if status == v1.ConditionTrue {
+		fusionaccess.Status.Status = fusionv1alpha1.ConditionUpgrading
+	}
+	setCondition(fusionaccess, fusionv1alpha1.ConditionUpgrading, status, reason, fmt.Sprintf("Upgrade from %s to %s: %s", from, to, message))
+	return r.updateStatus(ctx, fusionaccess)
+}
+
+// isDeploymentRolledOut returns true once the latest generation of a deployment is available.
+// A deployment that does not exist is returned as a NotFound error
+func (r *FusionAccessReconciler) isDeploymentRolledOut(ctx context.Context, key types.NamespacedName) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, key, deployment); err != nil {
+		return false, err
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas, nil
+}
+
+// pendingCoreDaemons returns the core daemon pods that are not yet running the given coreInit image
+// or are not ready. No core daemons exist before a Storage Scale cluster has been created
+func (r *FusionAccessReconciler) pendingCoreDaemons(ctx context.Context, coreInit string) ([]string, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(IBMCoreNamespace),
+		client.MatchingLabels{IBMCoreDaemonLabel: IBMCoreDaemonValue}); err != nil {
+		return nil, err
+	}
+	pending := []string{}
+	for _, pod := range pods.Items {
+		images := []string{}
+		for _, c := range pod.Spec.InitContainers {
+			images = append(images, c.Image)
+		}
+		if !slices.Contains(images, coreInit) || !isPodReady(&pod) {
+			pending = append(pending, pod.Name)
+		}
+	}
+	return pending, nil
+}
+
+// manifestDeployments returns the deployments in the manifest
+func manifestDeployments(installManifest manifestival.Manifest) []types.NamespacedName {
+	deployments := []types.NamespacedName{}
+	for _, res := range installManifest.Filter(manifestival.ByKind("Deployment")).Resources() {
+		deployments = append(deployments, types.NamespacedName{Namespace: res.GetNamespace(), Name: res.GetName()})
+	}
+	return deployments
+}
+
+// getManifestCoreInitImage returns the coreInit image referenced by the IBM operator configuration in the manifest
+func getManifestCoreInitImage(installManifest manifestival.Manifest) (string, error) {
+	configMaps := installManifest.Filter(
+		manifestival.ByKind("ConfigMap"),
+		manifestival.ByName("ibm-spectrum-scale-manager-config"),
+	).Resources()
+	if len(configMaps) == 0 {
+		return "", fmt.Errorf("ConfigMap object in install yaml not found")
+	}
+	content, err := yaml.Marshal(configMaps[0].Object)
+	if err != nil {
+		return "", err
+	}
+	return utils.ParseYAMLAndExtractTestImage(string(content))
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
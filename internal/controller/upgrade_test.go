@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+
+	mfc "github.com/manifestival/controller-runtime-client"
+	"github.com/manifestival/manifestival"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("Storage Scale upgrades", func() {
+	ctx := context.Background()
+
+	Describe("isUpgrade", func() {
+		It("is false on a fresh install", func() {
+			fa := &fusionv1alpha.FusionAccess{Spec: fusionv1alpha.FusionAccessSpec{StorageScaleVersion: "v5.2.3.1.dev3"}}
+			Expect(isUpgrade(fa)).To(BeFalse())
+		})
+
+		It("is false when the installed version matches", func() {
+			fa := &fusionv1alpha.FusionAccess{
+				Spec:   fusionv1alpha.FusionAccessSpec{StorageScaleVersion: "v5.2.3.1.dev3"},
+				Status: fusionv1alpha.FusionAccessStatus{InstalledStorageScaleVersion: "v5.2.3.1.dev3"},
+			}
+			Expect(isUpgrade(fa)).To(BeFalse())
+		})
+
+		It("is false when the versions only differ in the leading v", func() {
+			fa := &fusionv1alpha.FusionAccess{
+				Spec:   fusionv1alpha.FusionAccessSpec{StorageScaleVersion: "v5.2.3.1.dev3"},
+				Status: fusionv1alpha.FusionAccessStatus{InstalledStorageScaleVersion: "5.2.3.1.dev3"},
+			}
+			Expect(isUpgrade(fa)).To(BeFalse())
+		})
+
+		It("is true when a new version is requested", func() {
+			fa := &fusionv1alpha.FusionAccess{
+				Spec:   fusionv1alpha.FusionAccessSpec{StorageScaleVersion: "v5.2.3.1.dev3"},
+				Status: fusionv1alpha.FusionAccessStatus{InstalledStorageScaleVersion: "v5.2.3.0"},
+			}
+			Expect(isUpgrade(fa)).To(BeTrue())
+		})
+	})
+
+	Describe("getManifestCoreInitImage", func() {
+		It("returns the coreInit image of the shipped manifest", func() {
+			installManifest, err := manifestival.NewManifest("../../files/v5.2.3.1.dev3/install.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			image, err := getManifestCoreInitImage(installManifest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image).To(Equal("quay.io/openshift-storage-scale/ibm-spectrum-scale-core-init:5.2.3.1.dev3"))
+		})
+	})
+
+	Describe("manifestDeployments", func() {
+		It("returns the deployments of the shipped manifest", func() {
+			installManifest, err := manifestival.NewManifest("../../files/v5.2.3.1.dev3/install.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manifestDeployments(installManifest)).To(ContainElements(IBMOperatorDeployments))
+		})
+	})
+
+	Describe("isDeploymentRolledOut", func() {
+		key := types.NamespacedName{Namespace: "ibm-spectrum-scale-operator", Name: "ibm-spectrum-scale-controller-manager"}
+
+		It("waits for the new replicas to be available", func() {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+			}
+			r := newFakeReconciler([]client.Object{deployment})
+			rolledOut, err := r.isDeploymentRolledOut(ctx, key)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rolledOut).To(BeFalse())
+		})
+
+		It("is done once the latest generation is available", func() {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 1, AvailableReplicas: 1},
+			}
+			r := newFakeReconciler([]client.Object{deployment})
+			rolledOut, err := r.isDeploymentRolledOut(ctx, key)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rolledOut).To(BeTrue())
+		})
+
+		It("returns an error for a deployment that does not exist", func() {
+			r := newFakeReconciler(nil)
+			rolledOut, err := r.isDeploymentRolledOut(ctx, key)
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+			Expect(rolledOut).To(BeFalse())
+		})
+	})
+
+	Describe("pendingCoreDaemons", func() {
+		newCorePod := func(name, image string, ready corev1.ConditionStatus) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: IBMCoreNamespace,
+					Labels:    map[string]string{IBMCoreDaemonLabel: IBMCoreDaemonValue},
+				},
+				Spec: corev1.PodSpec{InitContainers: []corev1.Container{{Name: "config", Image: image}}},
+				Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: ready},
+				}},
+			}
+		}
+
+		It("lists the daemons that are not ready on the new image", func() {
+			r := newFakeReconciler([]client.Object{
+				newCorePod("worker0", "core-init:new", corev1.ConditionTrue),
+				newCorePod("worker1", "core-init:old", corev1.ConditionTrue),
+				newCorePod("worker2", "core-init:new", corev1.ConditionFalse),
+			})
+			pending, err := r.pendingCoreDaemons(ctx, "core-init:new")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(ConsistOf("worker1", "worker2"))
+		})
+	})
+
+	Context("When the requested version is a downgrade", func() {
+		BeforeEach(func() {
+			os.Setenv("DEPLOYMENT_NAMESPACE", "ibm-fusion-access-operator")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("DEPLOYMENT_NAMESPACE")
+		})
+
+		It("refuses to apply the manifest", func() {
+			resource := &fusionv1alpha.FusionAccess{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       resourceName,
+					Namespace:  "default",
+					Finalizers: []string{storageScaleFinalizer},
+				},
+				Spec:   fusionv1alpha.FusionAccessSpec{StorageScaleVersion: "v5.2.3.1.dev3"},
+				Status: fusionv1alpha.FusionAccessStatus{InstalledStorageScaleVersion: "v5.2.3.1"},
+			}
+			r := newFakeReconciler([]client.Object{resource}, withStatusSubresource(&fusionv1alpha.FusionAccess{}))
+			r.fullClient = kubeclient.NewSimpleClientset()
+			key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+			// Retrying does not help, so no error is returned
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).ToNot(HaveOccurred())
+
+			updated := &fusionv1alpha.FusionAccess{}
+			Expect(r.Get(ctx, key, updated)).To(Succeed())
+			cond := meta.FindStatusCondition(updated.Status.Conditions, fusionv1alpha.ConditionUpgrading)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Reason).To(Equal("UpgradeNotAllowed"))
+			Expect(cond.Message).To(ContainSubstring("v5.2.3.1 to v5.2.3.1.dev3"))
+			Expect(cond.Message).To(ContainSubstring("downgrading"))
+			Expect(updated.Status.InstalledStorageScaleVersion).To(Equal("v5.2.3.1"))
+
+			// Nothing from the manifest was applied
+			installManifest, err := manifestival.NewManifest("../../files/v5.2.3.1.dev3/install.yaml",
+				manifestival.UseClient(mfc.NewClient(r.Client)))
+			Expect(err).ToNot(HaveOccurred())
+			namespaces := installManifest.Filter(manifestival.ByKind("Namespace")).Resources()
+			Expect(r.Get(ctx, types.NamespacedName{Name: namespaces[0].GetName()}, &corev1.Namespace{})).ToNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,182 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	configv1 "github.com/openshift/api/config/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+// operatorConditionNameEnvVar is set by OLM to the name of the OperatorCondition of this operator
+const operatorConditionNameEnvVar = "OPERATOR_CONDITION_NAME"
+
+// operatorConditionGVK is the OLM OperatorCondition, the type is not vendored so it is used unstructured
+var operatorConditionGVK = schema.GroupVersionKind{
+	Group:   "operators.coreos.com",
+	Version: "v2",
+	Kind:    "OperatorCondition",
+}
+
+// reconcileUpgradeable checks the current OpenShift version and the updates offered to the cluster against
+// the OpenShift levels of the installed Storage Scale release. When one of them is not supported, OLM is told
+// that the operator is not upgradeable, which blocks OpenShift minor upgrades
+func (r *FusionAccessReconciler) reconcileUpgradeable(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) error {
+	version := fusionaccess.Status.InstalledStorageScaleVersion
+	if version == "" {
+		version = string(fusionaccess.Spec.StorageScaleVersion)
+	}
+	if version == "" {
+		// The levels of an external manifest are not known
+		setCondition(fusionaccess, fusionv1alpha1.ConditionUpgradeable, v1.ConditionTrue,
+			fusionv1alpha1.ReasonNotApplicable, "No Storage Scale version is set, the OpenShift levels cannot be checked")
+		return r.setOperatorUpgradeable(ctx, v1.ConditionTrue, fusionv1alpha1.ReasonNotApplicable,
+			"No Storage Scale version is set")
+	}
+
+	clusterVersion := &configv1.ClusterVersion{}
+	if err := r.Get(ctx, types.NamespacedName{Name: "version"}, clusterVersion); err != nil {
+		return fmt.Errorf("failed to get the ClusterVersion: %w", err)
+	}
+	status, reason, message, err := openShiftUpgradeable(version, clusterVersion)
+	if err != nil {
+		return err
+	}
+	setCondition(fusionaccess, fusionv1alpha1.ConditionUpgradeable, status, reason, message)
+	return r.setOperatorUpgradeable(ctx, status, reason, message)
+}
+
+// openShiftUpgradeable returns the Upgradeable condition for a Storage Scale version on the given cluster
+func openShiftUpgradeable(version string, clusterVersion *configv1.ClusterVersion) (v1.ConditionStatus, string, string, error) {
+	release, err := utils.GetStorageScaleRelease(version)
+	if err != nil {
+		return "", "", "", err
+	}
+	levels := strings.Join(release.OpenShiftLevels, ", ")
+
+	current, err := utils.GetCurrentClusterVersion(clusterVersion)
+	if err != nil {
+		return "", "", "", err
+	}
+	if !utils.IsOpenShiftSupported(version, *current) {
+		return v1.ConditionFalse, fusionv1alpha1.ReasonOpenShiftVersionUnsupported,
+			fmt.Sprintf("OpenShift %s is not supported by IBM Storage Scale %s, which supports OpenShift %s",
+				current, version, levels), nil
+	}
+
+	updates := []string{}
+	for _, update := range clusterVersion.Status.AvailableUpdates {
+		updates = append(updates, update.Version)
+	}
+	for _, update := range clusterVersion.Status.ConditionalUpdates {
+		updates = append(updates, update.Release.Version)
+	}
+	unsupported := []string{}
+	for _, update := range updates {
+		updateVersion, err := semver.NewVersion(update)
+		if err != nil {
+			log.Log.Info("Ignoring an OpenShift update with an invalid version", "version", update)
+			continue
+		}
+		if !utils.IsOpenShiftSupported(version, *updateVersion) && !slices.Contains(unsupported, update) {
+			unsupported = append(unsupported, update)
+		}
+	}
+	if len(unsupported) > 0 {
+		return v1.ConditionFalse, fusionv1alpha1.ReasonOpenShiftUpdateUnsupported,
+			fmt.Sprintf("Upgrading OpenShift to %s is not supported by IBM Storage Scale %s, which supports OpenShift %s. "+
+				"Upgrade IBM Storage Scale first", strings.Join(unsupported, ", "), version, levels), nil
+	}
+	return v1.ConditionTrue, fusionv1alpha1.ReasonOpenShiftVersionSupported,
+		fmt.Sprintf("OpenShift %s and its available updates are supported by IBM Storage Scale %s", current, version), nil
+}
+
+// setOperatorUpgradeable sets the Upgradeable condition of the OLM OperatorCondition. Nothing is done
+// when the operator is not installed by OLM
+func (r *FusionAccessReconciler) setOperatorUpgradeable(ctx context.Context, status v1.ConditionStatus, reason, message string) error {
+	name, found := os.LookupEnv(operatorConditionNameEnvVar)
+	if !found || name == "" {
+		return nil
+	}
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return err
+	}
+
+	operatorCondition := &unstructured.Unstructured{}
+	operatorCondition.SetGroupVersionKind(operatorConditionGVK)
+	err = r.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, operatorCondition)
+	if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		log.Log.Info("OperatorCondition not found, not reporting Upgradeable", "name", name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get OperatorCondition %s: %w", name, err)
+	}
+
+	conditions := []v1.Condition{}
+	existing, _, _ := unstructured.NestedSlice(operatorCondition.Object, "spec", "conditions")
+	for _, item := range existing {
+		u, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		cond := v1.Condition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u, &cond); err != nil {
+			return fmt.Errorf("failed to read the conditions of OperatorCondition %s: %w", name, err)
+		}
+		conditions = append(conditions, cond)
+	}
+	if !meta.SetStatusCondition(&conditions, v1.Condition{
+		Type:    "Upgradeable",
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}) {
+		return nil
+	}
+
+	raw := make([]any, 0, len(conditions))
+	for _, cond := range conditions {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&cond)
+		if err != nil {
+			return err
+		}
+		raw = append(raw, u)
+	}
+	if err := unstructured.SetNestedSlice(operatorCondition.Object, raw, "spec", "conditions"); err != nil {
+		return err
+	}
+	log.Log.Info("Updating the Upgradeable condition of the OperatorCondition", "status", status, "reason", reason)
+	return r.Update(ctx, operatorCondition)
+}
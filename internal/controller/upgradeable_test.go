@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("Upgradeable", func() {
+	const ns = "ibm-fusion-access-operator"
+	var (
+		ctx          = context.Background()
+		cl           client.Client
+		r            *FusionAccessReconciler
+		fusionaccess *fusionv1alpha.FusionAccess
+	)
+
+	newOperatorCondition := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(operatorConditionGVK)
+		u.SetName("openshift-fusion-access-operator.v1.0.0")
+		u.SetNamespace(ns)
+		Expect(unstructured.SetNestedSlice(u.Object, []any{map[string]any{
+			"type": "Other", "status": "True", "reason": "Other", "message": "",
+			"lastTransitionTime": "2025-01-01T00:00:00Z",
+		}}, "spec", "conditions")).To(Succeed())
+		return u
+	}
+
+	withUpdates := func(current string, updates ...string) *configv1.ClusterVersion {
+		cv := newOCPVersion(current)
+		for _, update := range updates {
+			cv.Status.AvailableUpdates = append(cv.Status.AvailableUpdates, configv1.Release{Version: update})
+		}
+		return cv
+	}
+
+	setup := func(objs ...client.Object) {
+		r = newFakeReconciler(objs)
+		cl = r.Client
+	}
+
+	getUpgradeable := func() *metav1.Condition {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(operatorConditionGVK)
+		Expect(cl.Get(ctx, types.NamespacedName{Namespace: ns, Name: "openshift-fusion-access-operator.v1.0.0"}, u)).To(Succeed())
+		raw, _, _ := unstructured.NestedSlice(u.Object, "spec", "conditions")
+		for _, item := range raw {
+			cond := item.(map[string]any)
+			if cond["type"] == "Upgradeable" {
+				Expect(raw).To(HaveLen(2))
+				return &metav1.Condition{
+					Status:  metav1.ConditionStatus(cond["status"].(string)),
+					Reason:  cond["reason"].(string),
+					Message: cond["message"].(string),
+				}
+			}
+		}
+		return nil
+	}
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", ns)
+		GinkgoT().Setenv(operatorConditionNameEnvVar, "openshift-fusion-access-operator.v1.0.0")
+		fusionaccess = &fusionv1alpha.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: ns},
+			Spec:       fusionv1alpha.FusionAccessSpec{StorageScaleVersion: "v5.2.3.1.dev3"},
+		}
+	})
+
+	It("is upgradeable when the current version and the updates are supported", func() {
+		setup(withUpdates("4.17.3", "4.17.5", "4.18.1"), newOperatorCondition())
+		Expect(r.reconcileUpgradeable(ctx, fusionaccess)).To(Succeed())
+
+		cond := getUpgradeable()
+		Expect(cond).ToNot(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(meta.IsStatusConditionTrue(fusionaccess.Status.Conditions, fusionv1alpha.ConditionUpgradeable)).To(BeTrue())
+	})
+
+	It("is not upgradeable when an update is not supported", func() {
+		setup(withUpdates("4.18.1", "4.18.2", "4.19.0"), newOperatorCondition())
+		Expect(r.reconcileUpgradeable(ctx, fusionaccess)).To(Succeed())
+
+		cond := getUpgradeable()
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonOpenShiftUpdateUnsupported))
+		Expect(cond.Message).To(ContainSubstring("Upgrading OpenShift to 4.19.0 is not supported by IBM Storage Scale v5.2.3.1.dev3"))
+		Expect(cond.Message).ToNot(ContainSubstring("4.18.2"))
+	})
+
+	It("is not upgradeable when the current version is not supported", func() {
+		setup(withUpdates("4.19.0"), newOperatorCondition())
+		Expect(r.reconcileUpgradeable(ctx, fusionaccess)).To(Succeed())
+
+		cond := getUpgradeable()
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(fusionv1alpha.ReasonOpenShiftVersionUnsupported))
+	})
+
+	It("checks the installed version rather than the requested one", func() {
+		fusionaccess.Status.InstalledStorageScaleVersion = "v5.2.3.1.dev3"
+		fusionaccess.Spec.StorageScaleVersion = "v9.9.9.9"
+		setup(withUpdates("4.18.1"), newOperatorCondition())
+		Expect(r.reconcileUpgradeable(ctx, fusionaccess)).To(Succeed())
+		Expect(getUpgradeable().Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("does nothing without OLM", func() {
+		GinkgoT().Setenv(operatorConditionNameEnvVar, "")
+		setup(withUpdates("4.19.0"))
+		Expect(r.reconcileUpgradeable(ctx, fusionaccess)).To(Succeed())
+		Expect(meta.IsStatusConditionFalse(fusionaccess.Status.Conditions, fusionv1alpha.ConditionUpgradeable)).To(BeTrue())
+	})
+})
@@ -24,6 +24,9 @@ const (
 	udevEventPeriod               = 5 * time.Second
 	probeInterval                 = 5 * time.Minute
 	resultCRName                  = "discovery-result-%s"
+	// unverifiedSignature is reported for the devices that could not be checked for existing data, so that
+	// they are treated as if they held some
+	unverifiedSignature = "unverified"
 )
 
 var supportedDeviceTypes = sets.NewString("mpath", "disk")
@@ -132,7 +135,7 @@ func (discovery *DeviceDiscovery) discoverDevices() error {
 
 	klog.Infof("valid block devices: %+v", validDevices)
 
-	discoveredDisks := getDiscoverdDevices(validDevices)
+	discoveredDisks := checkExistingData(getDiscoverdDevices(validDevices))
 	klog.Infof("discovered devices: %+v", discoveredDisks)
 
 	// Update discovered devices in the  LocalVolumeDiscoveryResult resource
@@ -212,6 +215,23 @@ func getDiscoverdDevices(blockDevices []diskutils.BlockDevice) []v1alpha1.Discov
 	return uniqueDevices(discoveredDevices)
 }
 
+// checkExistingData looks for partition tables, filesystems, LVM and GPFS NSD descriptors that lsblk does not
+// report, e.g. on LUNs that are used by another cluster, so that they are not handed to Storage Scale unnoticed
+func checkExistingData(devices []v1alpha1.DiscoveredDevice) []v1alpha1.DiscoveredDevice {
+	for idx := range devices {
+		signatures, err := diskutils.GetDataSignatures(devices[idx].Path)
+		if err != nil {
+			klog.Warningf("failed to check the device %q for existing data. Error %v", devices[idx].Path, err)
+			signatures = []string{unverifiedSignature}
+		}
+		if len(signatures) > 0 {
+			klog.Infof("device %q holds existing data: %v", devices[idx].Path, signatures)
+			devices[idx].ExistingData = signatures
+		}
+	}
+	return devices
+}
+
 // uniqueDevices removes duplicate devices from the list using WWN as a key
 func uniqueDevices(sample []v1alpha1.DiscoveredDevice) []v1alpha1.DiscoveredDevice {
 	var unique []v1alpha1.DiscoveredDevice
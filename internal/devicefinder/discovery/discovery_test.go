@@ -3,7 +3,9 @@ package discovery
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -186,4 +188,40 @@ var _ = Describe("Device Discovery", func() {
 		})
 
 	})
+
+	Context("When checking the devices for existing data", func() {
+		AfterEach(func() {
+			diskutils.ExecCommand = diskutils.CmdExec{}
+		})
+
+		It("reports the signatures found on the devices", func() {
+			blank := filepath.Join(GinkgoT().TempDir(), "sdb")
+			Expect(os.WriteFile(blank, make([]byte, 4096), 0o600)).To(Succeed())
+			diskutils.ExecCommand = &fakeExecutor{output: []byte(`{"signatures":[{"device":"sdb","offset":"0x0","type":"xfs"}]}`)}
+
+			devices := checkExistingData([]v1alpha1.DiscoveredDevice{{Path: blank, WWN: "0x5000c50015ff75aa"}})
+			Expect(devices[0].ExistingData).To(Equal([]string{"xfs"}))
+		})
+
+		It("reports the devices that cannot be checked as unverified", func() {
+			diskutils.ExecCommand = &fakeExecutor{err: errors.New("wipefs not found")}
+
+			devices := checkExistingData([]v1alpha1.DiscoveredDevice{{Path: "/dev/sdb", WWN: "0x5000c50015ff75aa"}})
+			Expect(devices[0].ExistingData).To(Equal([]string{unverifiedSignature}))
+		})
+	})
 })
+
+// fakeExecutor returns the same output for every command
+type fakeExecutor struct {
+	output []byte
+	err    error
+}
+
+func (f *fakeExecutor) Execute(_ string, _ ...string) diskutils.Command {
+	return f
+}
+
+func (f *fakeExecutor) CombinedOutput() ([]byte, error) {
+	return f.output, f.err
+}
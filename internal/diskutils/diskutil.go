@@ -1,8 +1,14 @@
 package diskutils
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"slices"
 	"strings"
 
 	"k8s.io/klog/v2"
@@ -19,8 +25,16 @@ func init() {
 const (
 	// StateSuspended is a possible value of BlockDevice.State
 	StateSuspended = "suspended"
+	// GPFSSignature is reported for devices that carry a GPFS NSD descriptor, which wipefs does not know
+	GPFSSignature = "gpfs_nsd"
+	// gpfsDescriptorSize is how much of a device is read to find the NSD descriptor GPFS writes in its
+	// second sector
+	gpfsDescriptorSize = 8 * 512
 )
 
+// gpfsDescriptorMarker is part of the text GPFS writes in the NSD descriptor
+var gpfsDescriptorMarker = []byte("NSD descriptor")
+
 type CommandExecutor interface {
 	Execute(name string, args ...string) Command
 }
@@ -117,3 +131,57 @@ func GetBlockDevices() ([]byte, error) {
 	}
 	return output, err
 }
+
+// signatureList is the output of wipefs --json
+type signatureList struct {
+	Signatures []struct {
+		Type string `json:"type"`
+	} `json:"signatures"`
+}
+
+// GetDataSignatures returns the sorted types of the partition tables, filesystems, LVM and RAID signatures
+// found on the device, and GPFSSignature if it carries a GPFS NSD descriptor. Nothing is written to the device
+func GetDataSignatures(path string) ([]string, error) {
+	cmd := ExecCommand.Execute("wipefs", "--no-act", "--json", path)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run wipefs on %s: %s", path, err)
+	}
+	signatures := []string{}
+	// wipefs prints nothing when the device has no signature
+	if len(bytes.TrimSpace(output)) > 0 {
+		list := signatureList{}
+		if err := json.Unmarshal(output, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse the wipefs output for %s: %w", path, err)
+		}
+		for _, signature := range list.Signatures {
+			signatures = append(signatures, signature.Type)
+		}
+	}
+
+	gpfs, err := HasGPFSDescriptor(path)
+	if err != nil {
+		return nil, err
+	}
+	if gpfs {
+		signatures = append(signatures, GPFSSignature)
+	}
+	slices.Sort(signatures)
+	return slices.Compact(signatures), nil
+}
+
+// HasGPFSDescriptor returns true if the device was used as a GPFS NSD
+func HasGPFSDescriptor(path string) (bool, error) {
+	device, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer device.Close()
+
+	buf := make([]byte, gpfsDescriptorSize)
+	n, err := io.ReadFull(device, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return bytes.Contains(buf[:n], gpfsDescriptorMarker), nil
+}
@@ -2,6 +2,9 @@ package diskutils
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -144,3 +147,46 @@ var _ = Describe("GetBlockDevices", func() {
 		Expect(output).To(BeEmpty())
 	})
 })
+
+var _ = Describe("GetDataSignatures", func() {
+	device := func(content string) string {
+		path := filepath.Join(GinkgoT().TempDir(), "sdb")
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	It("returns the signatures found by wipefs", func() {
+		ExecCommand = &fakeExecutor{
+			cmd: &fakeCommand{output: []byte(`{"signatures": [
+				{"device":"sdb","offset":"0x200","type":"gpt","uuid":"0d1c","label":null},
+				{"device":"sdb","offset":"0x218","type":"LVM2_member","uuid":"x1Yz","label":null},
+				{"device":"sdb","offset":"0x1fffffe00","type":"gpt","uuid":"0d1c","label":null}
+			]}`)},
+		}
+		signatures, err := GetDataSignatures(device(""))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signatures).To(Equal([]string{"LVM2_member", "gpt"}))
+	})
+
+	It("finds the GPFS NSD descriptor", func() {
+		ExecCommand = &fakeExecutor{cmd: &fakeCommand{}}
+		signatures, err := GetDataSignatures(device(strings.Repeat("\x00", 512) + "NSD descriptor for /dev/sdb created by GPFS"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signatures).To(Equal([]string{GPFSSignature}))
+	})
+
+	It("returns nothing for a blank device", func() {
+		ExecCommand = &fakeExecutor{cmd: &fakeCommand{}}
+		signatures, err := GetDataSignatures(device(strings.Repeat("\x00", 8192)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signatures).To(BeEmpty())
+	})
+
+	It("returns error when wipefs fails", func() {
+		ExecCommand = &fakeExecutor{
+			cmd: &fakeCommand{err: errors.New("command failed")},
+		}
+		_, err := GetDataSignatures(device(""))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeutils
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultEventInterval is how long an event is not recorded again for the same object
+const DefaultEventInterval = 10 * time.Minute
+
+// EventRecorder records Kubernetes events on behalf of a reconciler. Reconcilers run the same steps
+// over and over, so an event that repeats the type, reason and message of one recorded for the same
+// object within the interval is dropped. A nil EventRecorder records nothing
+type EventRecorder struct {
+	recorder record.EventRecorder
+	interval time.Duration
+
+	mux      sync.Mutex
+	recorded map[string]time.Time
+}
+
+// NewEventRecorder returns an EventRecorder that records through recorder
+func NewEventRecorder(recorder record.EventRecorder, interval time.Duration) *EventRecorder {
+	return &EventRecorder{
+		recorder: recorder,
+		interval: interval,
+		recorded: map[string]time.Time{},
+	}
+}
+
+// Event records an event for obj unless the same event was recorded within the interval
+func (r *EventRecorder) Event(obj client.Object, eventType, reason, message string) {
+	if r == nil || r.recorder == nil {
+		return
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	now := time.Now()
+	key := fmt.Sprintf("%s/%s/%s:%s:%s:%s", obj.GetNamespace(), obj.GetName(), obj.GetUID(), eventType, reason, message)
+	if last, ok := r.recorded[key]; ok && now.Sub(last) < r.interval {
+		return
+	}
+	r.recorder.Event(obj, eventType, reason, message)
+	r.recorded[key] = now
+
+	// Forget about the events that cannot be dropped anymore, so that the map does not keep growing
+	for k, last := range r.recorded {
+		if now.Sub(last) >= r.interval {
+			delete(r.recorded, k)
+		}
+	}
+}
+
+// Eventf is like Event, with a message built with fmt.Sprintf
+func (r *EventRecorder) Eventf(obj client.Object, eventType, reason, messageFmt string, args ...any) {
+	r.Event(obj, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
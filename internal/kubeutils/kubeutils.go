@@ -20,9 +20,13 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
 )
 
 // CreateOrUpdateResource performs a create-or-update reconciliation for any Kubernetes object.
@@ -68,3 +72,48 @@ func CreateOrUpdateResource[T client.Object](
 	fmt.Printf("%T %s/%s: %s\n", desired, desired.GetNamespace(), desired.GetName(), op)
 	return nil
 }
+
+// SetOwner labels obj with the name and namespace of owner, so that the reconciler of owner is triggered
+// when obj is changed or deleted. When both live in the same namespace owner is also set as the
+// controller of obj, which is then garbage collected with it. Cluster-scoped objects only get the labels
+// as they cannot be owned by a namespaced object
+func SetOwner(owner, obj client.Object, scheme *runtime.Scheme) error {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[common.OwnerNameLabel] = owner.GetName()
+	labels[common.OwnerNamespaceLabel] = owner.GetNamespace()
+	obj.SetLabels(labels)
+
+	if obj.GetNamespace() == "" || obj.GetNamespace() != owner.GetNamespace() {
+		return nil
+	}
+	return controllerutil.SetControllerReference(owner, obj, scheme)
+}
+
+// OwnerRequests enqueues the owner recorded in the labels set by SetOwner
+func OwnerRequests(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	name, ok := labels[common.OwnerNameLabel]
+	if !ok {
+		return []reconcile.Request{}
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: labels[common.OwnerNamespaceLabel]}}}
+}
+
+// CopyOwner copies the labels and the owner references set by SetOwner from desired to existing, for
+// use in the mutate functions of CreateOrUpdateResource
+func CopyOwner(existing, desired client.Object) {
+	labels := existing.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for _, key := range []string{common.OwnerNameLabel, common.OwnerNamespaceLabel} {
+		if value, ok := desired.GetLabels()[key]; ok {
+			labels[key] = value
+		}
+	}
+	existing.SetLabels(labels)
+	existing.SetOwnerReferences(desired.GetOwnerReferences())
+}
@@ -17,20 +17,31 @@ limitations under the License.
 package utils
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
 	configv1 "github.com/openshift/api/config/v1"
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -40,49 +51,221 @@ const (
 	CheckPodContainerName       = "check"
 )
 
-// Taken from https://www.ibm.com/docs/en/scalecontainernative/5.2.2?topic=planning-software-requirements
+// IBMFilesystemGVK is the GroupVersionKind of the IBM Storage Scale Filesystem resource
+var IBMFilesystemGVK = schema.GroupVersionKind{
+	Group:   "scale.spectrum.ibm.com",
+	Version: "v1beta1",
+	Kind:    "Filesystem",
+}
+
+// IBMClusterGVK is the GroupVersionKind of the IBM Storage Scale Cluster resource
+var IBMClusterGVK = schema.GroupVersionKind{
+	Group:   "scale.spectrum.ibm.com",
+	Version: "v1beta1",
+	Kind:    "Cluster",
+}
+
+// IBMLocalDiskGVK is the GroupVersionKind of the IBM Storage Scale LocalDisk resource
+var IBMLocalDiskGVK = schema.GroupVersionKind{
+	Group:   "scale.spectrum.ibm.com",
+	Version: "v1beta1",
+	Kind:    "LocalDisk",
+}
+
+// ReleaseMetadataFile is the name of the metadata document shipped next to each install.yaml in files/<version>/
+const ReleaseMetadataFile = "metadata.yaml"
+
+// FusionAccessData is the metadata of an IBM Storage Scale container native release as found in
+// files/<version>/metadata.yaml. See the IBM software requirements for each release, e.g.
+// https://www.ibm.com/docs/en/scalecontainernative/5.2.2?topic=planning-software-requirements
 type FusionAccessData struct {
-	CSIVersion                string   `json:"csi_version"`
-	Architecture              []string `json:"architecture"`
-	RemoteStorageClusterLevel string   `json:"remote_storage_cluster_level"`
-	FileSystemVersion         string   `json:"file_system_version"`
-	OpenShiftLevels           []string `json:"openshift_levels"`
-}
-
-// The dict key is the IBM Fusion Access Container Native version
-var storageScaleTable = map[string]FusionAccessData{
-	"5.2.2.0": {
-		"2.13.0",
-		[]string{"x86_64", "ppc64le", "s390x"},
-		"5.1.9.0+",
-		"36.00",
-		[]string{"4.15", "4.16", "4.17"},
-	},
-	"5.2.2.1": {
-		"2.13.1",
-		[]string{"x86_64", "ppc64le", "s390x"},
-		"5.1.9.0+",
-		"36.00",
-		[]string{"4.15", "4.16", "4.17", "4.18"},
-	},
-	"5.2.3.0": {
-		"2.13.1",
-		[]string{"x86_64", "ppc64le", "s390x"},
-		"5.1.9.0+",
-		"36.00",
-		[]string{"4.16", "4.17", "4.18"},
-	},
+	// Version is the name of the files/ directory the metadata was loaded from
+	Version                   string   `yaml:"-"`
+	CSIVersion                string   `yaml:"csiVersion"`
+	Architecture              []string `yaml:"architectures"`
+	RemoteStorageClusterLevel string   `yaml:"remoteStorageClusterLevel"`
+	FileSystemVersion         string   `yaml:"fileSystemVersion"`
+	OpenShiftLevels           []string `yaml:"openshiftLevels"`
+	// UpgradeFrom lists the versions that can be upgraded in place to this version
+	UpgradeFrom []string `yaml:"upgradeFrom"`
 }
 
-func IsOpenShiftSupported(ibmFusionAccessVersion string, openShiftVersion semver.Version) bool {
-	// Strip the leading "v" from the IBM Fusion Access version
-	ibmVer := ibmFusionAccessVersion
-	if strings.HasPrefix(ibmFusionAccessVersion, "v") {
-		ibmVer = ibmFusionAccessVersion[1:] // Remove the first character
+// ErrUnknownStorageScaleVersion is returned for a version that is not in the release catalog
+var ErrUnknownStorageScaleVersion = errors.New("IBM Storage Scale version is not available")
+
+// storageScaleReleases returns the release catalog keyed by version without the leading "v".
+// It is only read from disk once and can be replaced in tests
+var storageScaleReleases = sync.OnceValues(func() (map[string]FusionAccessData, error) {
+	filesDir, err := GetFilesDir()
+	if err != nil {
+		return nil, err
+	}
+	return LoadStorageScaleReleases(filesDir)
+})
+
+// LoadStorageScaleReleases reads the metadata of every release directory found in filesDir.
+// Directories without a metadata file are skipped as they cannot be validated
+func LoadStorageScaleReleases(filesDir string) (map[string]FusionAccessData, error) {
+	entries, err := os.ReadDir(filesDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read release directory %s: %w", filesDir, err)
+	}
+	releases := map[string]FusionAccessData{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(path.Join(filesDir, entry.Name(), ReleaseMetadataFile))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var data FusionAccessData
+		if err := yaml.Unmarshal(content, &data); err != nil {
+			return nil, fmt.Errorf("failed to parse %s metadata of %s: %w", ReleaseMetadataFile, entry.Name(), err)
+		}
+		if _, _, err := splitStorageScaleVersion(entry.Name()); err != nil {
+			return nil, err
+		}
+		data.Version = entry.Name()
+		releases[strings.TrimPrefix(entry.Name(), "v")] = data
+	}
+	return releases, nil
+}
+
+// GetStorageScaleReleases returns all the IBM Storage Scale releases shipped with the operator, oldest first
+func GetStorageScaleReleases() ([]FusionAccessData, error) {
+	releases, err := storageScaleReleases()
+	if err != nil {
+		return nil, err
 	}
+	result := make([]FusionAccessData, 0, len(releases))
+	for _, data := range releases {
+		result = append(result, data)
+	}
+	slices.SortFunc(result, func(a, b FusionAccessData) int {
+		c, _ := CompareStorageScaleVersions(a.Version, b.Version)
+		return c
+	})
+	return result, nil
+}
 
-	data, exists := storageScaleTable[ibmVer]
+// GetStorageScaleRelease returns the metadata of the given IBM Storage Scale version, with or without the leading "v"
+func GetStorageScaleRelease(version string) (FusionAccessData, error) {
+	releases, err := storageScaleReleases()
+	if err != nil {
+		return FusionAccessData{}, err
+	}
+	data, exists := releases[strings.TrimPrefix(version, "v")]
 	if !exists {
+		return FusionAccessData{}, fmt.Errorf("%w: %s", ErrUnknownStorageScaleVersion, version)
+	}
+	return data, nil
+}
+
+// ValidateStorageScaleVersion makes sure the requested version is one of the releases shipped with the
+// operator. An empty version is valid, the webhook defaults it
+func ValidateStorageScaleVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+	if _, err := GetStorageScaleRelease(version); err != nil {
+		releases, lerr := GetStorageScaleReleases()
+		if lerr != nil {
+			return errors.Join(err, lerr)
+		}
+		available := make([]string, 0, len(releases))
+		for _, release := range releases {
+			available = append(available, release.Version)
+		}
+		return fmt.Errorf("%w, available versions: %s", err, strings.Join(available, ", "))
+	}
+	return nil
+}
+
+// CompareStorageScaleVersions compares two IBM Storage Scale versions (e.g. v5.2.3.1 or 5.2.3.1.dev3)
+// and returns -1, 0 or 1 like strings.Compare. Numeric fields are compared numerically and a version
+// with a suffix (e.g. .dev3) sorts before the same version without it
+func CompareStorageScaleVersions(a, b string) (int, error) {
+	aNums, aSuffix, err := splitStorageScaleVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bNums, bSuffix, err := splitStorageScaleVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < max(len(aNums), len(bNums)); i++ {
+		var x, y int
+		if i < len(aNums) {
+			x = aNums[i]
+		}
+		if i < len(bNums) {
+			y = bNums[i]
+		}
+		if x != y {
+			return cmp.Compare(x, y), nil
+		}
+	}
+	switch {
+	case aSuffix == bSuffix:
+		return 0, nil
+	case aSuffix == "":
+		return 1, nil
+	case bSuffix == "":
+		return -1, nil
+	}
+	return strings.Compare(aSuffix, bSuffix), nil
+}
+
+func splitStorageScaleVersion(version string) ([]int, string, error) {
+	fields := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	nums := []int{}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			if i == 0 {
+				return nil, "", fmt.Errorf("invalid Storage Scale version %s", version)
+			}
+			return nums, strings.Join(fields[i:], "."), nil
+		}
+		nums = append(nums, n)
+	}
+	return nums, "", nil
+}
+
+// IsStorageScaleUpgradeAllowed returns an error if moving an existing installation from one Storage Scale
+// version to another is not supported. Downgrades are never allowed and upgrades must be listed in the
+// UpgradeFrom field of the target version
+func IsStorageScaleUpgradeAllowed(from, to string) error {
+	c, err := CompareStorageScaleVersions(from, to)
+	if err != nil {
+		return err
+	}
+	if c == 0 {
+		return nil
+	}
+	if c > 0 {
+		return fmt.Errorf("downgrading IBM Storage Scale from %s to %s is not supported", from, to)
+	}
+	data, err := GetStorageScaleRelease(to)
+	if err != nil {
+		return fmt.Errorf("no upgrade path data found for IBM Storage Scale version %s: %w", to, err)
+	}
+	if !slices.ContainsFunc(data.UpgradeFrom, func(v string) bool {
+		return strings.TrimPrefix(v, "v") == strings.TrimPrefix(from, "v")
+	}) {
+		return fmt.Errorf("upgrading IBM Storage Scale from %s to %s is not supported, %s can only be upgraded from %v",
+			from, to, to, data.UpgradeFrom)
+	}
+	return nil
+}
+
+func IsOpenShiftSupported(ibmFusionAccessVersion string, openShiftVersion semver.Version) bool {
+	data, err := GetStorageScaleRelease(ibmFusionAccessVersion)
+	if err != nil {
 		return false
 	}
 
@@ -99,6 +282,73 @@ func IsOpenShiftSupported(ibmFusionAccessVersion string, openShiftVersion semver
 	return false
 }
 
+// storageScaleArchitectures maps the architectures reported by the nodes to the names used in the release metadata
+var storageScaleArchitectures = map[string]string{
+	"amd64": "x86_64",
+	"arm64": "aarch64",
+}
+
+// UnsupportedArchitectures returns the node architectures that the Storage Scale version does not support.
+// A release whose metadata does not list any architecture is not restricted
+func UnsupportedArchitectures(ibmFusionAccessVersion string, nodeArchitectures []string) ([]string, error) {
+	data, err := GetStorageScaleRelease(ibmFusionAccessVersion)
+	if err != nil {
+		return nil, err
+	}
+	unsupported := []string{}
+	if len(data.Architecture) == 0 {
+		return unsupported, nil
+	}
+	for _, arch := range nodeArchitectures {
+		name, ok := storageScaleArchitectures[arch]
+		if !ok {
+			name = arch
+		}
+		if !slices.Contains(data.Architecture, name) && !slices.Contains(unsupported, name) {
+			unsupported = append(unsupported, name)
+		}
+	}
+	return unsupported, nil
+}
+
+// CheckStorageScaleSupport returns an error explaining why the Storage Scale version is not supported
+// on the OpenShift version or on the architectures of the nodes
+func CheckStorageScaleSupport(ibmFusionAccessVersion string, openShiftVersion semver.Version, nodeArchitectures []string) error {
+	data, err := GetStorageScaleRelease(ibmFusionAccessVersion)
+	if err != nil {
+		return err
+	}
+	if !IsOpenShiftSupported(ibmFusionAccessVersion, openShiftVersion) {
+		return fmt.Errorf("IBM Storage Scale %s does not support OpenShift %s, supported OpenShift levels: %s",
+			ibmFusionAccessVersion, openShiftVersion.String(), strings.Join(data.OpenShiftLevels, ", "))
+	}
+	unsupported, err := UnsupportedArchitectures(ibmFusionAccessVersion, nodeArchitectures)
+	if err != nil {
+		return err
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("IBM Storage Scale %s does not support the %s node architectures, supported architectures: %s",
+			ibmFusionAccessVersion, strings.Join(unsupported, ", "), strings.Join(data.Architecture, ", "))
+	}
+	return nil
+}
+
+// NewestSupportedStorageScaleRelease returns the newest shipped release that supports the OpenShift version
+// and the architectures of the nodes
+func NewestSupportedStorageScaleRelease(openShiftVersion semver.Version, nodeArchitectures []string) (FusionAccessData, error) {
+	releases, err := GetStorageScaleReleases()
+	if err != nil {
+		return FusionAccessData{}, err
+	}
+	for _, release := range slices.Backward(releases) {
+		if CheckStorageScaleSupport(release.Version, openShiftVersion, nodeArchitectures) == nil {
+			return release, nil
+		}
+	}
+	return FusionAccessData{}, fmt.Errorf("no IBM Storage Scale release shipped with the operator supports OpenShift %s on %s",
+		openShiftVersion.String(), strings.Join(nodeArchitectures, ", "))
+}
+
 // status:
 //  history:
 //   - completionTime: null
@@ -152,6 +402,12 @@ func GetDeploymentNamespace() (string, error) {
 	return ns, nil
 }
 
+// WebhooksEnabled returns false when the operator is started with ENABLE_WEBHOOKS=false, e.g. when it
+// runs outside of the cluster
+func WebhooksEnabled() bool {
+	return os.Getenv("ENABLE_WEBHOOKS") != "false"
+}
+
 type ConfigMap struct {
 	Kind     string            `yaml:"kind"`
 	Metadata map[string]any    `yaml:"metadata"`
@@ -230,17 +486,8 @@ func GetExternalTestImage(cnsaVersion string) (string, error) {
 	return image, nil
 }
 
-// CreateImageCheckPod creates a pod with the specified image and returns its name.
-func CreateImageCheckPod(
-	ctx context.Context,
-	client kubernetes.Interface,
-	namespace, image, imagePullSecretName string,
-) (string, error) {
-	existingPod, err := client.CoreV1().Pods(namespace).Get(ctx, CheckPodName, metav1.GetOptions{})
-	if err == nil {
-		return existingPod.Name, nil // Pod already exists
-	}
-
+// NewImageCheckPod returns a pod that only succeeds in starting if the image can be pulled
+func NewImageCheckPod(namespace, image, imagePullSecretName string) *corev1.Pod {
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      CheckPodName,
@@ -263,99 +510,181 @@ func CreateImageCheckPod(
 			{Name: imagePullSecretName},
 		}
 	}
-	createdPod, err := client.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
-	if err != nil {
-		return "", fmt.Errorf("failed to create pod: %w", err)
-	}
-
-	return createdPod.Name, nil
+	return pod
 }
 
-// PollPodPullStatus checks if a pod successfully pulled its image or hit an error.
-func PollPodPullStatus(
-	ctx context.Context,
-	client kubernetes.Interface,
-	namespace, podName string,
-) (bool, error) {
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ctx.Done():
-			return false, fmt.Errorf("timeout while checking image pull status")
-		case <-ticker.C:
-			pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
-			if err != nil {
-				return false, fmt.Errorf("failed to get pod: %w", err)
-			}
-
-			if len(pod.Status.ContainerStatuses) == 0 {
-				continue
-			}
+// GetPodPullStatus inspects a pod once without waiting. done is false as long as the kubelet has not
+// either started the container or given up pulling its image. When done, err explains a failed pull
+func GetPodPullStatus(pod *corev1.Pod) (done bool, err error) {
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return false, nil
+	}
 
-			state := pod.Status.ContainerStatuses[0].State
-			if state.Waiting != nil {
-				switch state.Waiting.Reason {
-				case "ErrImagePull", "ImagePullBackOff":
-					return false, fmt.Errorf("image pull failed: %s", state.Waiting.Message)
-				}
-			} else if state.Running != nil || state.Terminated != nil {
-				return true, nil
-			}
+	state := pod.Status.ContainerStatuses[0].State
+	if state.Waiting != nil {
+		switch state.Waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff":
+			return true, fmt.Errorf("image pull failed: %s", state.Waiting.Message)
 		}
+	} else if state.Running != nil || state.Terminated != nil {
+		return true, nil
 	}
+	return false, nil
 }
 
-var createPodFunc = CreateImageCheckPod
-var pollStatusFunc = PollPodPullStatus
+// GetFilesDir returns the directory holding one subdirectory per shipped IBM Storage Scale release
+func GetFilesDir() (string, error) {
+	for _, dir := range []string{
+		"../../files/", // when running tests
+		"files/",       // when running locally
+		"/files/",      // when running in container
+	} {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("could not find the files/ directory with the IBM Storage Scale releases")
+}
 
-// CanPullImage is a wrapper combining both steps.
-func CanPullImage(
-	ctx context.Context,
-	client kubernetes.Interface,
-	namespace, image, imagePullSecret string,
-) (bool, error) {
-	podName, err := createPodFunc(ctx, client, namespace, image, imagePullSecret)
+func GetInstallPath(cnsaVersion string) (string, error) {
+	filesDir, err := GetFilesDir()
 	if err != nil {
-		return false, err
+		return "", err
 	}
+	install_path := path.Join(filesDir, cnsaVersion, "install.yaml")
+	if _, err := os.Stat(install_path); err != nil {
+		return "", fmt.Errorf("could not find/open install file with version %s: %w", cnsaVersion, err)
+	}
+	return install_path, nil
+}
 
-	// Ensure cleanup
-	defer func() {
-		_ = client.CoreV1().
-			Pods(namespace).
-			Delete(context.Background(), podName, metav1.DeleteOptions{})
-	}()
+// ListIBMFilesystems returns the namespace/name of all the IBM Storage Scale Filesystems in the cluster.
+// If the IBM CRDs are not installed (yet or anymore) it returns an empty list
+func ListIBMFilesystems(ctx context.Context, cl client.Client) ([]string, error) {
+	fsList := &unstructured.UnstructuredList{}
+	fsList.SetGroupVersionKind(IBMFilesystemGVK.GroupVersion().WithKind(IBMFilesystemGVK.Kind + "List"))
+	if err := cl.List(ctx, fsList); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list IBM filesystems: %w", err)
+	}
+	filesystems := make([]string, 0, len(fsList.Items))
+	for _, fs := range fsList.Items {
+		filesystems = append(filesystems, fmt.Sprintf("%s/%s", fs.GetNamespace(), fs.GetName()))
+	}
+	return filesystems, nil
+}
 
-	return pollStatusFunc(ctx, client, namespace, podName)
+// GetNodeArchitectures returns the distinct CPU architectures of the nodes of the cluster, e.g. amd64
+func GetNodeArchitectures(ctx context.Context, cl client.Client) ([]string, error) {
+	nodes := &corev1.NodeList{}
+	if err := cl.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	architectures := []string{}
+	for _, node := range nodes.Items {
+		arch := node.Status.NodeInfo.Architecture
+		if arch != "" && !slices.Contains(architectures, arch) {
+			architectures = append(architectures, arch)
+		}
+	}
+	return architectures, nil
 }
 
-func GetInstallPath(cnsaVersion string) (string, error) {
-	// Install path when running tests
-	var err error
-	install_path := path.Join("../../files/", cnsaVersion, "install.yaml")
-	if _, err := os.Stat(install_path); err == nil {
-		return install_path, nil
+// ListIBMLocalDisks returns all the IBM Storage Scale LocalDisks in the cluster.
+// If the IBM CRDs are not installed (yet or anymore) it returns an empty list
+func ListIBMLocalDisks(ctx context.Context, cl client.Client) ([]unstructured.Unstructured, error) {
+	diskList := &unstructured.UnstructuredList{}
+	diskList.SetGroupVersionKind(IBMLocalDiskGVK.GroupVersion().WithKind(IBMLocalDiskGVK.Kind + "List"))
+	if err := cl.List(ctx, diskList); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list IBM local disks: %w", err)
 	}
-	// Install path when running locally
-	install_path = path.Join("files/", cnsaVersion, "install.yaml")
-	if _, err := os.Stat(install_path); err == nil {
-		return install_path, nil
+	return diskList.Items, nil
+}
+
+// GetClusterProxy returns the effective settings of the cluster-wide proxy, or nil when the cluster does
+// not use a proxy
+func GetClusterProxy(ctx context.Context, cl client.Client) (*configv1.ProxyStatus, error) {
+	proxy := &configv1.Proxy{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "cluster"}, proxy); err != nil {
+		if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get the cluster proxy: %w", err)
 	}
-	// Install path when running in container
-	install_path = path.Join("/files/", cnsaVersion, "install.yaml")
-	if _, err := os.Stat(install_path); err == nil {
-		return install_path, nil
+	if proxy.Status.HTTPProxy == "" && proxy.Status.HTTPSProxy == "" {
+		return nil, nil
 	}
+	return &proxy.Status, nil
+}
+
+const (
+	// TrustedCABundleConfigMap is created in the operator namespace and in the namespaces of the IBM
+	// Deployments. OpenShift injects the trusted CA bundle of the cluster, including the CA of the proxy, into it
+	TrustedCABundleConfigMap = "fusion-access-trusted-ca-bundle"
+	// InjectTrustedCABundleLabel asks OpenShift to inject the trusted CA bundle into a ConfigMap
+	InjectTrustedCABundleLabel = "config.openshift.io/inject-trusted-cabundle"
+	// TrustedCABundleKey is the key OpenShift injects the trusted CA bundle into
+	TrustedCABundleKey = "ca-bundle.crt"
+)
 
-	return "", fmt.Errorf("could not find/open install file with version %s: %w", cnsaVersion, err)
+// GetTrustedCABundle returns the trusted CA bundle injected into the TrustedCABundleConfigMap of the
+// namespace. It is empty as long as the ConfigMap does not exist or has not been injected yet
+func GetTrustedCABundle(ctx context.Context, cl client.Client, ns string) (string, error) {
+	cm := &corev1.ConfigMap{}
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: ns, Name: TrustedCABundleConfigMap}, cm); err != nil {
+		if kerrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get the trusted CA bundle: %w", err)
+	}
+	return cm.Data[TrustedCABundleKey], nil
 }
 
-func IsExternalManifestURLAllowed(url string) bool {
-	const allowedPrefix = "https://raw.githubusercontent.com/openshift-storage-scale"
-	url = strings.TrimSpace(url)
-	url = strings.ToLower(url)
-	return strings.HasPrefix(url, allowedPrefix)
+// ExternalManifestAllowedPrefixesEnvVar is a comma separated list of URL prefixes external manifests can be
+// fetched from. It replaces the default prefix and can be set on the operator Subscription
+const ExternalManifestAllowedPrefixesEnvVar = "EXTERNAL_MANIFEST_ALLOWED_PREFIXES"
+
+const defaultExternalManifestPrefix = "https://raw.githubusercontent.com/openshift-storage-scale"
+
+// GetExternalManifestAllowedPrefixes returns the lowercase URL prefixes external manifests can be fetched from
+func GetExternalManifestAllowedPrefixes() []string {
+	prefixes := []string{}
+	for _, prefix := range strings.Split(os.Getenv(ExternalManifestAllowedPrefixesEnvVar), ",") {
+		if prefix = strings.ToLower(strings.TrimSpace(prefix)); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	if len(prefixes) == 0 {
+		return []string{defaultExternalManifestPrefix}
+	}
+	return prefixes
+}
+
+// IsExternalManifestURLAllowed returns true when the URL has the scheme and host of an allowed prefix and its
+// path is the path of the prefix or below it. Whole path segments are compared, so the prefix of an
+// organization does not allow a sibling organization whose name starts the same
+func IsExternalManifestURLAllowed(manifestURL string) bool {
+	u, err := url.Parse(strings.TrimSpace(manifestURL))
+	if err != nil || u.Host == "" {
+		return false
+	}
+	urlPath := path.Clean("/" + strings.ToLower(u.Path))
+	for _, allowedPrefix := range GetExternalManifestAllowedPrefixes() {
+		allowed, err := url.Parse(allowedPrefix)
+		if err != nil || !strings.EqualFold(u.Scheme, allowed.Scheme) || !strings.EqualFold(u.Host, allowed.Host) {
+			continue
+		}
+		dir := strings.TrimSuffix(allowed.Path, "/") + "/"
+		if urlPath+"/" == dir || strings.HasPrefix(urlPath, dir) {
+			return true
+		}
+	}
+	return false
 }
 
 func mergeDockerConfigJSON(destRaw, srcRaw []byte) ([]byte, error) {
@@ -401,6 +730,26 @@ func mergeDockerConfigJSON(destRaw, srcRaw []byte) ([]byte, error) {
 	return json.Marshal(destCfg)
 }
 
+// DockerConfigRegistries returns the registries a .dockerconfigjson secret holds auths for, sorted
+func DockerConfigRegistries(secret *corev1.Secret) ([]string, error) {
+	raw := secret.Data[".dockerconfigjson"]
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var cfg struct {
+		Auths map[string]json.RawMessage `json:"auths"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid .dockerconfigjson in secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	registries := make([]string, 0, len(cfg.Auths))
+	for reg := range cfg.Auths {
+		registries = append(registries, reg)
+	}
+	slices.Sort(registries)
+	return registries, nil
+}
+
 func MergeSecrets(dest, src *corev1.Secret) (*corev1.Secret, error) {
 	if dest == nil || src == nil {
 		return nil, fmt.Errorf("cannot merge nil secrets")
@@ -442,3 +791,42 @@ func MergeSecrets(dest, src *corev1.Secret) (*corev1.Secret, error) {
 
 	return dest, nil
 }
+
+func unmergeDockerConfigJSON(destRaw []byte, registries []string) ([]byte, error) {
+	var destCfg map[string]any
+
+	if len(destRaw) == 0 {
+		return destRaw, nil
+	}
+	if err := json.Unmarshal(destRaw, &destCfg); err != nil {
+		return nil, fmt.Errorf("invalid dest .dockerconfigjson: %w", err)
+	}
+
+	// Only the given registries are removed, every other key is left untouched
+	destAuths, _ := destCfg["auths"].(map[string]any)
+	for _, reg := range registries {
+		delete(destAuths, reg)
+	}
+
+	return json.Marshal(destCfg)
+}
+
+// UnmergeSecrets is the inverse of MergeSecrets for .dockerconfigjson secrets: it removes the auths of
+// the registries from dest. The caller decides which registries were merged in, as dest may have held
+// some of them before
+func UnmergeSecrets(dest *corev1.Secret, registries []string) (*corev1.Secret, error) {
+	if dest == nil {
+		return nil, fmt.Errorf("cannot unmerge a nil secret")
+	}
+
+	if dest.Data == nil || len(registries) == 0 {
+		return dest, nil
+	}
+	unmergedJSON, err := unmergeDockerConfigJSON(dest.Data[".dockerconfigjson"], registries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmerge .dockerconfigjson: %w", err)
+	}
+	dest.Data[".dockerconfigjson"] = unmergedJSON
+
+	return dest, nil
+}
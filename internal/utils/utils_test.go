@@ -17,15 +17,12 @@ limitations under the License.
 package utils
 
 import (
-	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
-	"time"
 
 	"github.com/Masterminds/semver/v3"
 	. "github.com/onsi/ginkgo/v2"
@@ -33,8 +30,6 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestDevicefinder(t *testing.T) {
@@ -111,7 +106,81 @@ var _ = Describe("ParseAndReturnVersion", func() {
 	})
 })
 
+// testStorageScaleReleases replaces the release catalog shipped in files/ for the duration of a spec
+func testStorageScaleReleases() {
+	original := storageScaleReleases
+	storageScaleReleases = func() (map[string]FusionAccessData, error) {
+		return map[string]FusionAccessData{
+			"5.2.2.0": {Version: "v5.2.2.0", OpenShiftLevels: []string{"4.15", "4.16", "4.17"}},
+			"5.2.2.1": {Version: "v5.2.2.1", OpenShiftLevels: []string{"4.15", "4.16", "4.17", "4.18"},
+				UpgradeFrom: []string{"v5.2.2.0"}},
+			"5.2.3.0": {Version: "v5.2.3.0", OpenShiftLevels: []string{"4.16", "4.17", "4.18"},
+				Architecture: []string{"x86_64", "s390x"}, UpgradeFrom: []string{"v5.2.2.0", "v5.2.2.1"}},
+		}, nil
+	}
+	DeferCleanup(func() {
+		storageScaleReleases = original
+	})
+}
+
+var _ = Describe("LoadStorageScaleReleases", func() {
+	It("should load the releases shipped with the operator", func() {
+		releases, err := LoadStorageScaleReleases("../../files")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(releases).To(HaveKey("5.2.3.1.dev3"))
+		Expect(releases["5.2.3.1.dev3"].Version).To(Equal("v5.2.3.1.dev3"))
+		Expect(releases["5.2.3.1.dev3"].OpenShiftLevels).ToNot(BeEmpty())
+		Expect(releases["5.2.3.1.dev3"].CSIVersion).ToNot(BeEmpty())
+	})
+
+	It("should skip directories without metadata", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(dir, "v5.2.2.0"), 0o755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(dir, "v5.2.2.1"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "v5.2.2.1", ReleaseMetadataFile),
+			[]byte("csiVersion: \"2.13.1\"\nupgradeFrom:\n  - v5.2.2.0\n"), 0o600)).To(Succeed())
+		releases, err := LoadStorageScaleReleases(dir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(releases).To(HaveLen(1))
+		Expect(releases["5.2.2.1"].UpgradeFrom).To(Equal([]string{"v5.2.2.0"}))
+	})
+
+	It("should fail on invalid metadata", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(dir, "v5.2.2.0"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "v5.2.2.0", ReleaseMetadataFile),
+			[]byte("openshiftLevels: 4.18: 4.19"), 0o600)).To(Succeed())
+		_, err := LoadStorageScaleReleases(dir)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("GetStorageScaleReleases", func() {
+	BeforeEach(testStorageScaleReleases)
+
+	It("should return the releases oldest first", func() {
+		releases, err := GetStorageScaleReleases()
+		Expect(err).ToNot(HaveOccurred())
+		versions := []string{}
+		for _, r := range releases {
+			versions = append(versions, r.Version)
+		}
+		Expect(versions).To(Equal([]string{"v5.2.2.0", "v5.2.2.1", "v5.2.3.0"}))
+	})
+
+	It("should find a release with or without the leading v", func() {
+		_, err := GetStorageScaleRelease("v5.2.2.1")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = GetStorageScaleRelease("5.2.2.1")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = GetStorageScaleRelease("v5.2.1.1")
+		Expect(err).To(MatchError(ContainSubstring("not available")))
+	})
+})
+
 var _ = Describe("IsOpenShiftSupported", func() {
+	BeforeEach(testStorageScaleReleases)
+
 	DescribeTable("IBM version + OCP version matrix",
 		func(ibmVersion string, ocpVersion string, expected bool) {
 			version, err := semver.NewVersion(ocpVersion)
@@ -135,204 +204,134 @@ var _ = Describe("IsOpenShiftSupported", func() {
 	})
 })
 
-var _ = Describe("Image Pull Checker", func() {
-	var (
-		client      *fake.Clientset
-		namespace   string
-		image       string
-		testTimeout time.Duration
-	)
+var _ = Describe("CheckStorageScaleSupport", func() {
+	BeforeEach(testStorageScaleReleases)
 
-	BeforeEach(func() {
-		client = fake.NewSimpleClientset()
-		namespace = "default"
-		image = "test.registry.io/valid/image:latest"
-		testTimeout = 3 * time.Second
+	It("should accept a supported OpenShift version and architectures", func() {
+		Expect(CheckStorageScaleSupport("v5.2.3.0", *semver.MustParse("4.17.3"), []string{"amd64", "s390x"})).To(Succeed())
 	})
 
-	Describe("PollPodPullStatus", func() {
-		It("should detect ErrImagePull and return error", func() {
-			pod := &corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "image-fail-pod",
-					Namespace: namespace,
-				},
-				Status: corev1.PodStatus{
-					ContainerStatuses: []corev1.ContainerStatus{
-						{
-							State: corev1.ContainerState{
-								Waiting: &corev1.ContainerStateWaiting{
-									Reason:  "ErrImagePull",
-									Message: "image not found",
-								},
-							},
-						},
-					},
-				},
-			}
-
-			_, err := client.CoreV1().
-				Pods(namespace).
-				Create(context.TODO(), pod, metav1.CreateOptions{})
-			Expect(err).NotTo(HaveOccurred())
-
-			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
-			defer cancel()
-
-			success, err := PollPodPullStatus(ctx, client, namespace, pod.Name)
-			Expect(success).To(BeFalse())
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("image pull failed"))
-		})
-
-		It("should detect success if pod is Running", func() {
-			pod := &corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "image-success-pod",
-					Namespace: namespace,
-				},
-				Status: corev1.PodStatus{
-					ContainerStatuses: []corev1.ContainerStatus{
-						{
-							State: corev1.ContainerState{
-								Running: &corev1.ContainerStateRunning{
-									StartedAt: metav1.Now(),
-								},
-							},
-						},
-					},
-				},
-			}
-
-			_, err := client.CoreV1().
-				Pods(namespace).
-				Create(context.TODO(), pod, metav1.CreateOptions{})
-			Expect(err).NotTo(HaveOccurred())
+	It("should list the supported OpenShift levels", func() {
+		err := CheckStorageScaleSupport("v5.2.3.0", *semver.MustParse("4.19.0"), []string{"amd64"})
+		Expect(err).To(MatchError("IBM Storage Scale v5.2.3.0 does not support OpenShift 4.19.0, supported OpenShift levels: 4.16, 4.17, 4.18"))
+	})
 
-			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
-			defer cancel()
+	It("should report unsupported node architectures by their release name", func() {
+		unsupported, err := UnsupportedArchitectures("v5.2.3.0", []string{"amd64", "arm64", "arm64"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unsupported).To(Equal([]string{"aarch64"}))
+		err = CheckStorageScaleSupport("v5.2.3.0", *semver.MustParse("4.17.3"), []string{"arm64"})
+		Expect(err).To(MatchError(ContainSubstring("does not support the aarch64 node architectures")))
+	})
+})
 
-			success, err := PollPodPullStatus(ctx, client, namespace, pod.Name)
-			Expect(success).To(BeTrue())
-			Expect(err).NotTo(HaveOccurred())
-		})
+var _ = Describe("NewestSupportedStorageScaleRelease", func() {
+	BeforeEach(testStorageScaleReleases)
 
-		It("should timeout if pod never updates", func() {
-			pod := &corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "image-hang-pod",
-					Namespace: namespace,
-				},
-				Status: corev1.PodStatus{}, // no ContainerStatuses
-			}
+	It("should pick the newest release supporting the OpenShift version", func() {
+		release, err := NewestSupportedStorageScaleRelease(*semver.MustParse("4.17.3"), []string{"amd64"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(release.Version).To(Equal("v5.2.3.0"))
 
-			_, err := client.CoreV1().
-				Pods(namespace).
-				Create(context.TODO(), pod, metav1.CreateOptions{})
-			Expect(err).NotTo(HaveOccurred())
+		release, err = NewestSupportedStorageScaleRelease(*semver.MustParse("4.15.3"), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(release.Version).To(Equal("v5.2.2.1"))
+	})
 
-			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
-			defer cancel()
+	It("should skip releases that do not support the node architectures", func() {
+		release, err := NewestSupportedStorageScaleRelease(*semver.MustParse("4.17.3"), []string{"arm64"})
+		Expect(err).ToNot(HaveOccurred())
+		// The older releases of the test catalog do not restrict the architectures
+		Expect(release.Version).To(Equal("v5.2.2.1"))
+	})
 
-			success, err := PollPodPullStatus(ctx, client, namespace, pod.Name)
-			Expect(success).To(BeFalse())
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("timeout"))
-		})
+	It("should fail when no release supports the cluster", func() {
+		_, err := NewestSupportedStorageScaleRelease(*semver.MustParse("4.20.0"), []string{"amd64"})
+		Expect(err).To(MatchError("no IBM Storage Scale release shipped with the operator supports OpenShift 4.20.0 on amd64"))
 	})
+})
 
-	Describe("CreateImageCheckPod", func() {
-		It("should create a pod successfully", func() {
-			ctx := context.Background()
-			podName, err := CreateImageCheckPod(ctx, client, namespace, image, "")
-			Expect(err).NotTo(HaveOccurred())
-			Expect(podName).To(Equal(CheckPodName))
+var _ = Describe("CompareStorageScaleVersions", func() {
+	DescribeTable("version ordering",
+		func(a, b string, expected int) {
+			result, err := CompareStorageScaleVersions(a, b)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("equal versions", "5.2.3.0", "v5.2.3.0", 0),
+		Entry("older patch level", "5.2.2.1", "5.2.3.0", -1),
+		Entry("newer fix level", "v5.2.3.1", "v5.2.3.0", 1),
+		Entry("dev build sorts before the release", "5.2.3.1.dev3", "5.2.3.1", -1),
+		Entry("dev builds sort by suffix", "5.2.3.1.dev3", "5.2.3.1.dev2", 1),
+		Entry("missing fields are zero", "5.2.3", "5.2.3.0", 0),
+	)
 
-			pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(pod.Spec.Containers[0].Image).To(Equal(image))
-		})
+	It("should fail on invalid versions", func() {
+		_, err := CompareStorageScaleVersions("latest", "5.2.3.0")
+		Expect(err).To(HaveOccurred())
 	})
 })
 
-var _ = Describe("CanPullImage", func() {
-	const MaxPullTimeout = 5 * time.Second
-	var (
-		client         *fake.Clientset
-		namespace      string
-		image          string
-		originalCreate func(context.Context, kubernetes.Interface, string, string, string) (string, error)
-		originalPoll   func(context.Context, kubernetes.Interface, string, string) (bool, error)
-	)
+var _ = Describe("IsStorageScaleUpgradeAllowed", func() {
+	BeforeEach(testStorageScaleReleases)
 
-	BeforeEach(func() {
-		client = fake.NewSimpleClientset()
-		namespace = "default"
-		image = "quay.io/example/image:latest"
-		originalCreate = CreateImageCheckPod
-		originalPoll = PollPodPullStatus
+	It("should allow listed upgrade paths", func() {
+		Expect(IsStorageScaleUpgradeAllowed("v5.2.2.1", "v5.2.3.0")).To(Succeed())
 	})
 
-	AfterEach(func() {
-		defer func() {
-			createPodFunc = originalCreate
-			pollStatusFunc = originalPoll
-		}()
+	It("should allow staying on the same version", func() {
+		Expect(IsStorageScaleUpgradeAllowed("v5.2.3.0", "5.2.3.0")).To(Succeed())
 	})
 
-	It("returns true when pod is created and image is pullable", func() {
-		createPodFunc = func(ctx context.Context, clientset kubernetes.Interface, ns, img, pullSecret string) (string, error) {
-			// Simulate pod creation
-			pod := &corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "image-check-pod",
-					Namespace: ns,
-				},
-			}
-			_, err := clientset.CoreV1().Pods(ns).Create(ctx, pod, metav1.CreateOptions{})
-			Expect(err).NotTo(HaveOccurred())
-			return pod.Name, nil
-		}
+	It("should reject downgrades", func() {
+		err := IsStorageScaleUpgradeAllowed("5.2.3.0", "5.2.2.1")
+		Expect(err).To(MatchError(ContainSubstring("downgrading")))
+	})
 
-		pollStatusFunc = func(ctx context.Context, clientset kubernetes.Interface, ns, podName string) (bool, error) {
-			return true, nil
-		}
-		ctx, cancel := context.WithTimeout(context.Background(), MaxPullTimeout)
-		defer cancel()
+	It("should reject upgrades that are not in the graph", func() {
+		err := IsStorageScaleUpgradeAllowed("5.2.2.0", "5.2.2.5")
+		Expect(err).To(MatchError(ContainSubstring("no upgrade path data")))
+	})
+})
 
-		ok, err := CanPullImage(ctx, client, namespace, image, "")
-		Expect(err).ToNot(HaveOccurred())
-		Expect(ok).To(BeTrue())
+var _ = Describe("Image Pull Checker", func() {
+	podWithState := func(state corev1.ContainerState) *corev1.Pod {
+		return &corev1.Pod{Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{State: state}},
+		}}
+	}
 
-		_, err = client.CoreV1().Pods(namespace).Get(ctx, "image-check-pod", metav1.GetOptions{})
-		Expect(err).To(HaveOccurred()) // Pod should have been deleted
-	})
+	Describe("GetPodPullStatus", func() {
+		It("should detect ErrImagePull and return error", func() {
+			done, err := GetPodPullStatus(podWithState(corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull", Message: "image not found"},
+			}))
+			Expect(done).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("image pull failed")))
+		})
 
-	It("returns error if image cannot be pulled", func() {
-		createPodFunc = func(ctx context.Context, clientset kubernetes.Interface, ns, img, pullSecret string) (string, error) {
-			return "fail-pod", nil
-		}
-		pollStatusFunc = func(ctx context.Context, clientset kubernetes.Interface, ns, podName string) (bool, error) {
-			return false, errors.New("image pull failed")
-		}
-		ctx, cancel := context.WithTimeout(context.Background(), MaxPullTimeout)
-		defer cancel()
+		It("should detect success if pod is Running", func() {
+			done, err := GetPodPullStatus(podWithState(corev1.ContainerState{
+				Running: &corev1.ContainerStateRunning{StartedAt: metav1.Now()},
+			}))
+			Expect(done).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+		})
 
-		ok, err := CanPullImage(ctx, client, namespace, image, "")
-		Expect(err).To(HaveOccurred())
-		Expect(ok).To(BeFalse())
+		It("should not be done while the pod has no container status", func() {
+			done, err := GetPodPullStatus(&corev1.Pod{})
+			Expect(done).To(BeFalse())
+			Expect(err).NotTo(HaveOccurred())
+		})
 	})
 
-	It("returns error if pod creation fails", func() {
-		createPodFunc = func(ctx context.Context, clientset kubernetes.Interface, ns, img, pullSecret string) (string, error) {
-			return "", errors.New("create failed")
-		}
-		ctx, cancel := context.WithTimeout(context.Background(), MaxPullTimeout)
-		defer cancel()
-
-		ok, err := CanPullImage(ctx, client, namespace, image, "")
-		Expect(err).To(HaveOccurred())
-		Expect(ok).To(BeFalse())
+	Describe("NewImageCheckPod", func() {
+		It("should use the image and the pull secret", func() {
+			pod := NewImageCheckPod("default", "test.registry.io/valid/image:latest", "pull-secret")
+			Expect(pod.Name).To(Equal(CheckPodName))
+			Expect(pod.Spec.Containers[0].Image).To(Equal("test.registry.io/valid/image:latest"))
+			Expect(pod.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "pull-secret"}}))
+		})
 	})
 })
 
@@ -491,6 +490,21 @@ var _ = Describe("IsExternalManifestURLAllowed", func() {
 		Expect(IsExternalManifestURLAllowed(url)).To(BeFalse())
 	})
 
+	It("should not match a sibling organization with the same name prefix", func() {
+		url := "https://raw.githubusercontent.com/openshift-storage-scale-evil/project1/install.yaml"
+		Expect(IsExternalManifestURLAllowed(url)).To(BeFalse())
+	})
+
+	It("should not match a path that leaves the prefix", func() {
+		url := "https://raw.githubusercontent.com/openshift-storage-scale/../evil/install.yaml"
+		Expect(IsExternalManifestURLAllowed(url)).To(BeFalse())
+	})
+
+	It("should not match a host that only starts with the allowed host", func() {
+		url := "https://raw.githubusercontent.com.evil.example/openshift-storage-scale/install.yaml"
+		Expect(IsExternalManifestURLAllowed(url)).To(BeFalse())
+	})
+
 	It("should not match similar but incorrect host", func() {
 		url := "https://github.com/openshift-storage-scale/project1"
 		Expect(IsExternalManifestURLAllowed(url)).To(BeFalse())
@@ -505,6 +519,21 @@ var _ = Describe("IsExternalManifestURLAllowed", func() {
 		url := "some-random-string"
 		Expect(IsExternalManifestURLAllowed(url)).To(BeFalse())
 	})
+
+	It("should use the configured prefixes instead of the default one", func() {
+		GinkgoT().Setenv(ExternalManifestAllowedPrefixesEnvVar, " https://mirror.example.com/manifests/ ,HTTPS://Other.example.com/")
+		Expect(GetExternalManifestAllowedPrefixes()).To(Equal([]string{
+			"https://mirror.example.com/manifests/", "https://other.example.com/",
+		}))
+		Expect(IsExternalManifestURLAllowed("https://mirror.example.com/manifests/install.yaml")).To(BeTrue())
+		Expect(IsExternalManifestURLAllowed("https://other.example.com/install.yaml")).To(BeTrue())
+		Expect(IsExternalManifestURLAllowed("https://raw.githubusercontent.com/openshift-storage-scale/project1")).To(BeFalse())
+	})
+
+	It("should fall back to the default prefix when the configured list is empty", func() {
+		GinkgoT().Setenv(ExternalManifestAllowedPrefixesEnvVar, " , ")
+		Expect(IsExternalManifestURLAllowed("https://raw.githubusercontent.com/openshift-storage-scale/project1")).To(BeTrue())
+	})
 })
 
 var _ = Describe("MergeSecrets", func() {
@@ -678,6 +707,59 @@ var _ = Describe("MergeSecrets", func() {
 	})
 })
 
+var _ = Describe("UnmergeSecrets", func() {
+	It("returns an error when the secret is nil", func() {
+		_, err := UnmergeSecrets(nil, []string{"cp.icr.io"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("removes only the auths of the given registries", func() {
+		dest := &corev1.Secret{
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				".dockerconfigjson": mustMarshal(map[string]any{
+					"auths": map[string]any{
+						"quay.io":   map[string]any{"auth": "keep"},
+						"cp.icr.io": map[string]any{"auth": "drop"},
+					},
+				}),
+			},
+		}
+
+		unmerged, err := UnmergeSecrets(dest, []string{"cp.icr.io"})
+		Expect(err).ToNot(HaveOccurred())
+
+		var unmergedJSON map[string]any
+		Expect(json.Unmarshal(unmerged.Data[".dockerconfigjson"], &unmergedJSON)).To(Succeed())
+		auths := unmergedJSON["auths"].(map[string]any)
+		Expect(auths).To(HaveKey("quay.io"))
+		Expect(auths).ToNot(HaveKey("cp.icr.io"))
+	})
+
+	It("is a no-op without registries", func() {
+		dest := &corev1.Secret{
+			Data: map[string][]byte{".dockerconfigjson": []byte(`{"auths":{"quay.io":{}}}`)},
+		}
+		unmerged, err := UnmergeSecrets(dest, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unmerged.Data[".dockerconfigjson"]).To(Equal([]byte(`{"auths":{"quay.io":{}}}`)))
+	})
+})
+
+var _ = Describe("DockerConfigRegistries", func() {
+	It("lists the registries with auths", func() {
+		secret := &corev1.Secret{Data: map[string][]byte{
+			".dockerconfigjson": []byte(`{"auths":{"quay.io":{"auth":"a"},"cp.icr.io":{"auth":"b"}}}`),
+		}}
+		Expect(DockerConfigRegistries(secret)).To(Equal([]string{"cp.icr.io", "quay.io"}))
+	})
+
+	It("rejects an invalid .dockerconfigjson", func() {
+		_, err := DockerConfigRegistries(&corev1.Secret{Data: map[string][]byte{".dockerconfigjson": []byte("{")}})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
 func mustMarshal(obj any) []byte {
 	b, err := json.Marshal(obj)
 	Expect(err).ToNot(HaveOccurred())
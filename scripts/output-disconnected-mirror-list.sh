@@ -17,7 +17,7 @@ cat <<EOF
 kind: ImageSetConfiguration
 apiVersion: mirror.openshift.io/v2alpha1
 mirror:
-  additionalImages
+  additionalImages:
 EOF
 while IFS= read -r line; do
     echo "  - name: $line"